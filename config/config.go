@@ -0,0 +1,104 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kkumar-gcc/todo/constants"
+	"github.com/kkumar-gcc/todo/models"
+)
+
+// Config is the user configuration, read from config.yaml in the todo directory. Every setting is
+// optional and falls back to its default when left out.
+type Config struct {
+	Archive    ArchiveConfig              `yaml:"archive"`
+	Priorities models.PriorityScale       `yaml:"priorities"`
+	Urgency    models.UrgencyCoefficients `yaml:"urgency"`
+}
+
+// ArchiveConfig controls the automatic archiving of completed tasks.
+type ArchiveConfig struct {
+	AfterDays int `yaml:"after_days"` // Archive tasks this many days after they were completed, or never when zero
+}
+
+// Default returns the configuration used when there is no config file.
+func Default() *Config {
+	return &Config{
+		Priorities: models.DefaultPriorityScale(),
+		Urgency:    models.DefaultUrgencyCoefficients(),
+	}
+}
+
+// Dir returns the directory holding the todo configuration and database, creating it if needed.
+func Dir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(homeDir, ".config", "todo")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// Path returns the path to the config file.
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, constants.ConfigFileName), nil
+}
+
+// TemplatesDir returns the directory holding the task templates. It is not created, as templates are optional.
+func TemplatesDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, constants.TemplatesDirName), nil
+}
+
+// Load reads the config file, returning the default configuration when it does not exist.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Default(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) validate() error {
+	if c.Archive.AfterDays < 0 {
+		return errors.New("archive.after_days cannot be negative")
+	}
+	return c.Priorities.Validate()
+}
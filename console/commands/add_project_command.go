@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type AddProjectCommand struct {
+	ProjectService services.ProjectService
+}
+
+// Signature The name and signature of the console command.
+func (r *AddProjectCommand) Signature() string {
+	return "project:add"
+}
+
+// Description The console command description.
+func (r *AddProjectCommand) Description() string {
+	return "Create a new project"
+}
+
+// Extend The console command extend.
+func (r *AddProjectCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "projects",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:    "name",
+				Aliases: []string{"n"},
+				Usage:   "The name of the project",
+			},
+			&command.StringFlag{
+				Name:    "description",
+				Aliases: []string{"d"},
+				Usage:   "A short description of the project",
+			},
+			&command.StringFlag{
+				Name:    "color",
+				Aliases: []string{"c"},
+				Usage:   "The color used to display the project (red, green, yellow, blue, magenta, cyan, white, gray)",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *AddProjectCommand) Handle(ctx console.Context) (err error) {
+	name := ctx.Option("name")
+	if name == "" {
+		name, err = ctx.Ask("What is the name of the project?", console.AskOption{
+			Placeholder: "E.g., Website",
+			Prompt:      "> ",
+			Validate: func(value string) error {
+				if strings.TrimSpace(value) == "" {
+					return errors.New("the project name is required")
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+
+	project := &models.Project{
+		Name:        name,
+		Description: ctx.Option("description"),
+		Color:       ctx.Option("color"),
+	}
+
+	if err := r.ProjectService.CreateProject(context.Background(), project); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success("Project created successfully!")
+	return nil
+}
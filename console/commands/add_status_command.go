@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type AddStatusCommand struct {
+	StatusService services.StatusService
+}
+
+// Signature The name and signature of the console command.
+func (r *AddStatusCommand) Signature() string {
+	return "status:add"
+}
+
+// Description The console command description.
+func (r *AddStatusCommand) Description() string {
+	return "Add a status to the workflow"
+}
+
+// Extend The console command extend.
+func (r *AddStatusCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "statuses",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:    "name",
+				Aliases: []string{"n"},
+				Usage:   "The name of the status used on the command line, e.g. waiting-for-qa",
+			},
+			&command.StringFlag{
+				Name:    "label",
+				Aliases: []string{"l"},
+				Usage:   "The label shown for the status, defaults to its name",
+			},
+			&command.StringFlag{
+				Name:    "color",
+				Aliases: []string{"c"},
+				Usage:   "The color used to display the status (red, green, yellow, blue, magenta, cyan, white, gray)",
+			},
+			&command.StringFlag{
+				Name:  "category",
+				Usage: "How tasks in the status are treated: open, active or done",
+				Value: models.CategoryOpen,
+			},
+			&command.BoolFlag{
+				Name:  "requires-reason",
+				Usage: "Ask for a reason when a task moves to the status",
+			},
+			&command.StringSliceFlag{
+				Name:  "to",
+				Usage: "Statuses a task in the new status can move to; use status:allow to let tasks move into it",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *AddStatusCommand) Handle(ctx console.Context) (err error) {
+	name := ctx.Option("name")
+	if name == "" {
+		name, err = ctx.Ask("What is the name of the status?", console.AskOption{
+			Placeholder: "E.g., waiting-for-qa",
+			Prompt:      "> ",
+			Validate: func(value string) error {
+				if strings.TrimSpace(value) == "" {
+					return errors.New("the status name is required")
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+
+	status := &models.Status{
+		Name:           name,
+		Label:          ctx.Option("label"),
+		Color:          ctx.Option("color"),
+		Category:       ctx.Option("category"),
+		RequiresReason: ctx.OptionBool("requires-reason"),
+	}
+	for _, to := range ctx.OptionSlice("to") {
+		target, err := r.StatusService.GetStatus(context.Background(), to)
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		status.Transitions = append(status.Transitions, target.ID)
+	}
+
+	if err := r.StatusService.CreateStatus(context.Background(), status); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success("Status " + status.Name + " created successfully!")
+	if len(status.Transitions) == 0 {
+		ctx.Info("Tasks cannot leave the status yet, add moves with: todo status:allow --from " + status.Name + " --to <status>")
+	}
+	return nil
+}
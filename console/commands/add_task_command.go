@@ -1,8 +1,14 @@
 package commands
 
 import (
+	"bufio"
+	"cmp"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -10,11 +16,16 @@ import (
 	"github.com/goravel/framework/contracts/console/command"
 
 	"github.com/kkumar-gcc/todo/constants"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/quickadd"
 	"github.com/kkumar-gcc/todo/services"
 )
 
 type AddTaskCommand struct {
-	TaskService services.TaskService
+	TaskService    services.TaskService
+	ProjectService services.ProjectService
+	StatusService  services.StatusService
+	Priorities     models.PriorityScale
 }
 
 // Signature The name and signature of the console command.
@@ -24,7 +35,7 @@ func (r *AddTaskCommand) Signature() string {
 
 // Description The console command description.
 func (r *AddTaskCommand) Description() string {
-	return "Create a new task"
+	return "Create a new task, e.g. task:add \"Fix login bug +backend !high @in-progress due:fri\", or one for each line of a file with --from-file"
 }
 
 // Extend The console command extend.
@@ -40,28 +51,105 @@ func (r *AddTaskCommand) Extend() command.Extend {
 			&command.StringFlag{
 				Name:    "priority",
 				Aliases: []string{"p"},
-				Usage:   "The priority of the task (low, medium, high)",
+				Usage:   "The priority of the task, by name or rank; the levels are set in the config file",
 			},
 			&command.StringFlag{
 				Name:    "status",
 				Aliases: []string{"s"},
-				Usage:   "The status of the task (pending, in-progress, completed)",
+				Usage:   "The status of the task, by name or ID (e.g. pending, in-progress, completed)",
+			},
+			&command.StringFlag{
+				Name:  "reason",
+				Usage: "Why the task has its status, required for some statuses such as cancelled",
 			},
 			&command.StringFlag{
 				Name:    "tags",
 				Aliases: []string{"g"},
 				Usage:   "Tags for the task, separated by commas",
 			},
+			&command.StringFlag{
+				Name:    "due",
+				Aliases: []string{"d"},
+				Usage:   "The due date of the task (e.g. tomorrow, next fri, +3d, 2026-11-01 17:00)",
+			},
+			&command.StringFlag{
+				Name:    "repeat",
+				Aliases: []string{"r"},
+				Usage:   "Repeat the task when completed (daily, weekly, monthly, every 3 days, mon,wed,fri or an RRULE)",
+			},
+			&command.StringFlag{
+				Name:  "until",
+				Usage: "Stop repeating after this date",
+			},
+			&command.IntFlag{
+				Name:  "count",
+				Usage: "Stop repeating after this many occurrences",
+			},
+			&command.IntFlag{
+				Name:  "parent",
+				Usage: "The ID of the parent task, to create this task as a subtask",
+			},
+			&command.StringFlag{
+				Name:  "project",
+				Usage: "The name or ID of the project the task belongs to",
+			},
+			&command.StringFlag{
+				Name:  "estimate",
+				Usage: "The expected effort, as a duration (e.g. 30m, 2h) or in story points (e.g. 3pt)",
+			},
+			&command.StringFlag{
+				Name:  "notes",
+				Usage: "Markdown notes for the task",
+			},
+			&command.StringFlag{
+				Name:  "notes-file",
+				Usage: "Read the notes from a Markdown file, or \"-\" for standard input",
+			},
+			&command.StringFlag{
+				Name:  "from-file",
+				Usage: "Create a task for each line of a file, or \"-\" for standard input, written in the quick-add syntax",
+			},
+			&command.BoolFlag{
+				Name:  "literal",
+				Usage: "Take the title as it is, without reading +tag, !priority, @status or key:value words from it",
+			},
 		},
 	}
 }
 
 // Handle Execute the console command.
 func (r *AddTaskCommand) Handle(ctx console.Context) (err error) {
-	title := ctx.Option("title")
+	if source := ctx.Option("from-file"); source != "" || ctx.Argument(0) == "-" {
+		if source == "" {
+			source = "-"
+		}
+		return r.handleBulk(ctx, source)
+	}
+
+	// A title given as an argument is a quick add: whatever it leaves out gets its default instead of a prompt.
+	// Only such a title is read in the quick-add syntax; --title and the prompted title are taken as they are.
+	title, quick := ctx.Option("title"), len(ctx.Arguments()) > 0
+	parse := title == "" && quick && !ctx.OptionBool("literal")
+	if title == "" {
+		title = strings.Join(ctx.Arguments(), " ")
+	}
 	priority := ctx.Option("priority")
 	status := ctx.Option("status")
 	tags := ctx.Option("tags")
+	due := ctx.Option("due")
+	project := ctx.Option("project")
+	estimate := ctx.Option("estimate")
+	repeat := ctx.Option("repeat")
+	var parent string
+	if parentID := ctx.OptionInt("parent"); parentID != 0 {
+		parent = strconv.Itoa(parentID)
+	}
+
+	notes, _, err := readNotes(ctx.Option("notes"), ctx.Option("notes-file"))
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
 
 	if title == "" {
 		title, err = ctx.Ask("What is the title of the task?", console.AskOption{
@@ -80,14 +168,27 @@ func (r *AddTaskCommand) Handle(ctx console.Context) (err error) {
 		}
 	}
 
-	if priority == "" {
-		choices := []console.Choice{
-			{Key: constants.PriorityColors[constants.PriorityLow], Value: strconv.Itoa(constants.PriorityLow)},
-			{Key: constants.PriorityColors[constants.PriorityMedium], Value: strconv.Itoa(constants.PriorityMedium)},
-			{Key: constants.PriorityColors[constants.PriorityHigh], Value: strconv.Itoa(constants.PriorityHigh)},
+	if parse {
+		entry, err := quickadd.Parse(title)
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
 		}
-		priority, err = ctx.Choice("Select the priority of the task:", choices, console.ChoiceOption{
-			Default:     strconv.Itoa(constants.PriorityLow),
+		// Options given on the command line win over the words of the title.
+		title = entry.Title
+		priority = cmp.Or(priority, entry.Priority)
+		status = cmp.Or(status, entry.Status)
+		tags = strings.Join(append([]string{tags}, entry.Tags...), ",")
+		due = cmp.Or(due, entry.Due)
+		project = cmp.Or(project, entry.Project)
+		estimate = cmp.Or(estimate, entry.Estimate)
+		repeat = cmp.Or(repeat, entry.Repeat)
+		parent = cmp.Or(parent, entry.Parent)
+	}
+
+	if priority == "" && !quick {
+		priority, err = ctx.Choice("Select the priority of the task:", priorityChoices(r.Priorities), console.ChoiceOption{
+			Default:     strconv.Itoa(r.Priorities.DefaultRank()),
 			Description: "Choose a priority for the task",
 		})
 		if err != nil {
@@ -96,15 +197,18 @@ func (r *AddTaskCommand) Handle(ctx console.Context) (err error) {
 		}
 	}
 
+	if status == "" && quick {
+		status = strconv.Itoa(constants.StatusPending)
+	}
 	if status == "" {
-		choices := []console.Choice{
-			{Key: constants.StatusColors[constants.StatusPending], Value: strconv.Itoa(constants.StatusPending)},
-			{Key: constants.StatusColors[constants.StatusInProgress], Value: strconv.Itoa(constants.StatusInProgress)},
-			{Key: constants.StatusColors[constants.StatusCompleted], Value: strconv.Itoa(constants.StatusCompleted)},
+		workflow, err := r.StatusService.GetWorkflow(context.Background())
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
 		}
-		status, err = ctx.Choice("Select the status of the task:", choices, console.ChoiceOption{
+		status, err = ctx.Choice("Select the status of the task:", statusChoices(workflow.Statuses), console.ChoiceOption{
 			Default:     strconv.Itoa(constants.StatusPending),
-			Description: "Choose a priority for the task",
+			Description: "Choose a status for the task",
 		})
 		if err != nil {
 			ctx.Error(err.Error())
@@ -112,7 +216,21 @@ func (r *AddTaskCommand) Handle(ctx console.Context) (err error) {
 		}
 	}
 
-	if tags == "" {
+	taskStatus, err := r.StatusService.GetStatus(context.Background(), status)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	reason := ctx.Option("reason")
+	if taskStatus.RequiresReason && strings.TrimSpace(reason) == "" {
+		if reason, err = askReason(ctx, taskStatus); err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+
+	if tags == "" && !quick {
 		tags, err = ctx.Ask("Enter tags for the task (comma-separated):", console.AskOption{
 			Placeholder: "E.g., work,urgent",
 			Prompt:      "> ",
@@ -123,19 +241,74 @@ func (r *AddTaskCommand) Handle(ctx console.Context) (err error) {
 		}
 	}
 
-	priorityInt, err := strconv.Atoi(priority)
+	if due == "" && !quick {
+		due, err = ctx.Ask("Enter a due date for the task (optional):", console.AskOption{
+			Placeholder: "E.g., tomorrow, next fri, +3d, 2026-11-01 17:00",
+			Prompt:      "> ",
+			Validate: func(value string) error {
+				_, err := parseDue(value)
+				return err
+			},
+		})
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+
+	dueAt, err := parseDue(due)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	rrule, err := buildRecurrence(repeat, ctx.Option("until"), ctx.OptionInt("count"))
 	if err != nil {
 		ctx.Error(err.Error())
 		return nil
 	}
 
-	statusInt, err := strconv.Atoi(status)
+	taskEstimate, err := models.ParseEstimate(estimate)
 	if err != nil {
 		ctx.Error(err.Error())
 		return nil
 	}
 
-	if err := r.TaskService.CreateTask(context.Background(), title, statusInt, priorityInt, tags); err != nil {
+	priorityRank, err := resolvePriority(r.Priorities, priority)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	task := &models.Task{
+		Title:        title,
+		Notes:        notes,
+		Status:       taskStatus.ID,
+		StatusReason: reason,
+		Priority:     priorityRank,
+		Tags:         services.ParseTags(tags),
+		DueAt:        dueAt,
+		Recurrence:   rrule,
+		Estimate:     taskEstimate,
+	}
+	if parent != "" {
+		parentID, err := strconv.Atoi(parent)
+		if err != nil {
+			ctx.Error(fmt.Sprintf("the parent must be a task ID, not %q", parent))
+			return nil
+		}
+		task.ParentID = &parentID
+	}
+	if project != "" {
+		taskProject, err := r.ProjectService.GetProject(context.Background(), project)
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		task.ProjectID = &taskProject.ID
+	}
+
+	if err := r.TaskService.CreateTask(context.Background(), task); err != nil {
 		ctx.Error(err.Error())
 		return nil
 	}
@@ -143,3 +316,174 @@ func (r *AddTaskCommand) Handle(ctx console.Context) (err error) {
 	ctx.Success("Task created successfully!")
 	return nil
 }
+
+// handleBulk creates a task for each line read from a file, or from standard input when the source is "-".
+// The other options apply to every task. Blank lines and lines starting with # are skipped.
+func (r *AddTaskCommand) handleBulk(ctx console.Context, source string) error {
+	base, err := r.bulkDefaults(ctx)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	input := io.Reader(os.Stdin)
+	if source != "-" {
+		file, err := os.Open(source)
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		defer file.Close()
+		input = file
+	}
+
+	var tasks []*models.Task
+	var lines []int
+	statuses := make(map[string]*models.Status)
+	projects := make(map[string]*models.Project)
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		task := *base
+		task.Tags = slices.Clone(base.Tags)
+		if ctx.OptionBool("literal") {
+			task.Title = line
+		} else if err := r.applyEntry(ctx, &task, line, statuses, projects); err != nil {
+			ctx.Error(fmt.Sprintf("line %d: %v", number, err))
+			return nil
+		}
+		tasks = append(tasks, &task)
+		lines = append(lines, number)
+	}
+	if err := scanner.Err(); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	if len(tasks) == 0 {
+		ctx.Warning("No tasks to create, every line is blank or a comment.")
+		return nil
+	}
+
+	if err := r.TaskService.CreateTasks(context.Background(), tasks); err != nil {
+		var bulkErr *services.BulkTaskError
+		if errors.As(err, &bulkErr) {
+			ctx.Error(fmt.Sprintf("line %d: %v; no tasks were created", lines[bulkErr.Index], bulkErr.Err))
+			return nil
+		}
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success(fmt.Sprintf("Created %d tasks!", len(tasks)))
+	return nil
+}
+
+// bulkDefaults builds the task every line of a bulk import starts from, using the options given and no prompts.
+func (r *AddTaskCommand) bulkDefaults(ctx console.Context) (*models.Task, error) {
+	task := &models.Task{
+		Tags: services.ParseTags(ctx.Option("tags")),
+	}
+
+	if status := ctx.Option("status"); status != "" {
+		taskStatus, err := r.StatusService.GetStatus(context.Background(), status)
+		if err != nil {
+			return nil, err
+		}
+		task.Status = taskStatus.ID
+		task.StatusReason = ctx.Option("reason")
+	}
+
+	var err error
+	if task.Priority, err = resolvePriority(r.Priorities, ctx.Option("priority")); err != nil {
+		return nil, err
+	}
+	if task.DueAt, err = parseDue(ctx.Option("due")); err != nil {
+		return nil, err
+	}
+	if task.Recurrence, err = buildRecurrence(ctx.Option("repeat"), ctx.Option("until"), ctx.OptionInt("count")); err != nil {
+		return nil, err
+	}
+	if task.Estimate, err = models.ParseEstimate(ctx.Option("estimate")); err != nil {
+		return nil, err
+	}
+	if parentID := ctx.OptionInt("parent"); parentID != 0 {
+		task.ParentID = &parentID
+	}
+	if name := ctx.Option("project"); name != "" {
+		project, err := r.ProjectService.GetProject(context.Background(), name)
+		if err != nil {
+			return nil, err
+		}
+		task.ProjectID = &project.ID
+	}
+
+	return task, nil
+}
+
+// applyEntry fills a task in from a line written in the quick-add syntax, ending a repeat the line sets with
+// the --until and --count options. The statuses and projects the lines refer to are looked up once and kept
+// in the given maps.
+func (r *AddTaskCommand) applyEntry(ctx console.Context, task *models.Task, line string, statuses map[string]*models.Status, projects map[string]*models.Project) error {
+	entry, err := quickadd.Parse(line)
+	if err != nil {
+		return err
+	}
+
+	task.Title = entry.Title
+	task.Tags = append(task.Tags, entry.Tags...)
+	if entry.Priority != "" {
+		if task.Priority, err = resolvePriority(r.Priorities, entry.Priority); err != nil {
+			return err
+		}
+	}
+	if entry.Status != "" {
+		status, ok := statuses[entry.Status]
+		if !ok {
+			if status, err = r.StatusService.GetStatus(context.Background(), entry.Status); err != nil {
+				return err
+			}
+			statuses[entry.Status] = status
+		}
+		task.Status = status.ID
+	}
+	if entry.Due != "" {
+		if task.DueAt, err = parseDue(entry.Due); err != nil {
+			return err
+		}
+	}
+	if entry.Estimate != "" {
+		if task.Estimate, err = models.ParseEstimate(entry.Estimate); err != nil {
+			return err
+		}
+	}
+	if entry.Repeat != "" {
+		if task.Recurrence, err = buildRecurrence(entry.Repeat, ctx.Option("until"), ctx.OptionInt("count")); err != nil {
+			return err
+		}
+	}
+	if entry.Parent != "" {
+		parentID, err := strconv.Atoi(entry.Parent)
+		if err != nil {
+			return fmt.Errorf("the parent must be a task ID, not %q", entry.Parent)
+		}
+		task.ParentID = &parentID
+	}
+	if entry.Project != "" {
+		project, ok := projects[entry.Project]
+		if !ok {
+			if project, err = r.ProjectService.GetProject(context.Background(), entry.Project); err != nil {
+				return err
+			}
+			projects[entry.Project] = project
+		}
+		task.ProjectID = &project.ID
+	}
+
+	return nil
+}
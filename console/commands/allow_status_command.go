@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type AllowStatusCommand struct {
+	StatusService services.StatusService
+}
+
+// Signature The name and signature of the console command.
+func (r *AllowStatusCommand) Signature() string {
+	return "status:allow"
+}
+
+// Description The console command description.
+func (r *AllowStatusCommand) Description() string {
+	return "Let tasks move from one status to another"
+}
+
+// Extend The console command extend.
+func (r *AllowStatusCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "statuses",
+		Flags:    transitionFlags(),
+	}
+}
+
+// Handle Execute the console command.
+func (r *AllowStatusCommand) Handle(ctx console.Context) (err error) {
+	from, to := ctx.Option("from"), ctx.Option("to")
+	if from == "" || to == "" {
+		ctx.Error("both --from and --to are required")
+		return nil
+	}
+
+	if err := r.StatusService.AllowTransition(context.Background(), from, to); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success("Tasks can now move from " + from + " to " + to + ".")
+	return nil
+}
+
+// transitionFlags are the flags naming both ends of a transition.
+func transitionFlags() []command.Flag {
+	return []command.Flag{
+		&command.StringFlag{
+			Name:  "from",
+			Usage: "The status, by name or ID, tasks move from",
+		},
+		&command.StringFlag{
+			Name:  "to",
+			Usage: "The status, by name or ID, tasks move to",
+		},
+	}
+}
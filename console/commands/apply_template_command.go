@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type ApplyTemplateCommand struct {
+	TemplateService services.TemplateService
+	Priorities      models.PriorityScale
+}
+
+// Signature The name and signature of the console command.
+func (r *ApplyTemplateCommand) Signature() string {
+	return "template:apply"
+}
+
+// Description The console command description.
+func (r *ApplyTemplateCommand) Description() string {
+	return "Create the tasks of a template, e.g. template:apply release --var Version=1.4"
+}
+
+// Extend The console command extend.
+func (r *ApplyTemplateCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "templates",
+		Flags: []command.Flag{
+			&command.StringSliceFlag{
+				Name:  "var",
+				Usage: "Set a template variable, as NAME=VALUE (repeatable)",
+			},
+			&command.StringFlag{
+				Name:  "start",
+				Usage: "Count the relative due dates of the template from this date instead of now (e.g. \"next monday\", 2026-11-01)",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *ApplyTemplateCommand) Handle(ctx console.Context) (err error) {
+	name := strings.TrimSpace(ctx.Argument(0))
+	if name == "" {
+		ctx.Error("the template name is required, e.g. template:apply release")
+		return nil
+	}
+
+	// Options given after the template name are left unparsed, so read them here.
+	vars, startValue := ctx.OptionSlice("var"), ctx.Option("start")
+	trailing := flag.NewFlagSet(r.Signature(), flag.ContinueOnError)
+	trailing.SetOutput(io.Discard)
+	trailing.Func("var", "", func(value string) error {
+		vars = append(vars, value)
+		return nil
+	})
+	trailing.StringVar(&startValue, "start", startValue, "")
+	if err := trailing.Parse(ctx.Arguments()[1:]); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+	if trailing.NArg() > 0 {
+		ctx.Error(fmt.Sprintf("unexpected arguments: %s", strings.Join(trailing.Args(), " ")))
+		return nil
+	}
+
+	variables, err := parseVariables(vars)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	start := time.Now()
+	if value := strings.TrimSpace(startValue); value != "" {
+		if start, err = dates.Parse(value, start); err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+
+	tasks, err := r.TemplateService.ApplyTemplate(context.Background(), name, variables, start)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success(fmt.Sprintf("Created %d tasks from template %s", len(tasks), name))
+	ctx.NewLine()
+
+	depths := make(map[int]int, len(tasks))
+	for _, task := range tasks {
+		if task.ParentID != nil {
+			depths[task.ID] = depths[*task.ParentID] + 1
+		}
+
+		title := strings.Repeat("  ", depths[task.ID]) + color.Sprintf("%s (<fg=white;op=bold>%d</>)", task.Title, task.ID)
+		if task.DueAt != nil {
+			title += color.Sprintf(" <fg=gray>due %s</>", task.DueAt.Local().Format(time.RFC822))
+		}
+		ctx.TwoColumnDetail(title, priorityLabel(r.Priorities, task.Priority))
+	}
+	ctx.NewLine()
+
+	return nil
+}
+
+// parseVariables reads template variables given as NAME=VALUE.
+func parseVariables(values []string) (map[string]string, error) {
+	variables := make(map[string]string, len(values))
+	for _, value := range values {
+		name, content, ok := strings.Cut(value, "=")
+		if name = strings.TrimSpace(name); !ok || name == "" {
+			return nil, errors.New("template variables are given as NAME=VALUE, e.g. --var Version=1.4")
+		}
+		variables[name] = content
+	}
+	return variables, nil
+}
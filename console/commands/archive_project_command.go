@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type ArchiveProjectCommand struct {
+	ProjectService services.ProjectService
+}
+
+// Signature The name and signature of the console command.
+func (r *ArchiveProjectCommand) Signature() string {
+	return "project:archive"
+}
+
+// Description The console command description.
+func (r *ArchiveProjectCommand) Description() string {
+	return "Archive a project, hiding its tasks from the default task list"
+}
+
+// Extend The console command extend.
+func (r *ArchiveProjectCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "projects",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:     "name",
+				Aliases:  []string{"n"},
+				Usage:    "The name or ID of the project",
+				Required: true,
+			},
+			&command.BoolFlag{
+				Name:  "unarchive",
+				Usage: "Restore an archived project instead",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *ArchiveProjectCommand) Handle(ctx console.Context) (err error) {
+	archived := !ctx.OptionBool("unarchive")
+
+	if err := r.ProjectService.ArchiveProject(context.Background(), ctx.Option("name"), archived); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	if archived {
+		ctx.Success("Project archived successfully!")
+	} else {
+		ctx.Success("Project restored successfully!")
+	}
+	return nil
+}
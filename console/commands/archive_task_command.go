@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/config"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type ArchiveTaskCommand struct {
+	TaskService services.TaskService
+	Config      *config.Config
+}
+
+// Signature The name and signature of the console command.
+func (r *ArchiveTaskCommand) Signature() string {
+	return "task:archive"
+}
+
+// Description The console command description.
+func (r *ArchiveTaskCommand) Description() string {
+	return "Archive completed tasks out of the active list"
+}
+
+// Extend The console command extend.
+func (r *ArchiveTaskCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "tasks",
+		Flags: []command.Flag{
+			&command.IntSliceFlag{
+				Name:    "ids",
+				Aliases: []string{"i"},
+				Usage:   "Comma-separated IDs of the completed tasks to archive",
+			},
+			&command.StringFlag{
+				Name:  "completed-before",
+				Usage: "Archive every task completed before this date (e.g. 2026-09-01, or 30d for 30 days ago)",
+			},
+			&command.BoolFlag{
+				Name:  "auto",
+				Usage: "Apply the archive policy, archiving tasks completed more than archive.after_days days ago",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *ArchiveTaskCommand) Handle(ctx console.Context) (err error) {
+	ids := ctx.OptionIntSlice("ids")
+	completedBefore := ctx.Option("completed-before")
+	auto := ctx.OptionBool("auto")
+
+	var archived []int
+	switch {
+	case len(ids) > 0 && completedBefore != "", len(ids) > 0 && auto, completedBefore != "" && auto:
+		ctx.Error("Use only one of --ids, --completed-before and --auto")
+		return nil
+	case len(ids) > 0:
+		archived, err = r.TaskService.ArchiveTasks(context.Background(), ids)
+	case completedBefore != "":
+		before, parseErr := parseSince(completedBefore, time.Now())
+		if parseErr != nil {
+			ctx.Error(parseErr.Error())
+			return nil
+		}
+		archived, err = r.TaskService.ArchiveCompletedBefore(context.Background(), before)
+	case auto:
+		if r.Config.Archive.AfterDays <= 0 {
+			ctx.Error("The archive policy is off, set archive.after_days in the config to turn it on")
+			return nil
+		}
+		archived, err = r.TaskService.AutoArchive(context.Background(), r.Config.Archive.AfterDays)
+	default:
+		ctx.Error("Specify the tasks to archive with --ids, --completed-before or --auto")
+		return nil
+	}
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	if len(archived) == 0 {
+		ctx.Info("No completed tasks to archive.")
+		return nil
+	}
+
+	var skipped []int
+	for _, id := range ids {
+		if !slices.Contains(archived, id) {
+			skipped = append(skipped, id)
+		}
+	}
+	if len(skipped) > 0 {
+		ctx.Warning(fmt.Sprintf("Skipped task IDs that are not completed or already archived: %s", joinIDs(skipped)))
+	}
+
+	ctx.Success(fmt.Sprintf("Archived task IDs: %s", joinIDs(archived)))
+	return nil
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type DeleteTagCommand struct {
+	TagService services.TagService
+}
+
+// Signature The name and signature of the console command.
+func (r *DeleteTagCommand) Signature() string {
+	return "tag:delete"
+}
+
+// Description The console command description.
+func (r *DeleteTagCommand) Description() string {
+	return "Delete a tag and remove it from every task"
+}
+
+// Extend The console command extend.
+func (r *DeleteTagCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "tags",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:     "name",
+				Aliases:  []string{"n"},
+				Usage:    "The name of the tag to delete",
+				Required: true,
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *DeleteTagCommand) Handle(ctx console.Context) (err error) {
+	if err := r.TagService.DeleteTag(context.Background(), ctx.Option("name")); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success("Tag deleted successfully!")
+	return nil
+}
@@ -10,12 +10,19 @@ import (
 	"github.com/goravel/framework/contracts/console/command"
 	"github.com/goravel/framework/support/color"
 
-	"github.com/kkumar-gcc/todo/constants"
+	"github.com/kkumar-gcc/todo/models"
 	"github.com/kkumar-gcc/todo/services"
 )
 
+var subtaskPolicies = map[string]services.SubtaskPolicy{
+	"":         services.SubtasksReject,
+	"cascade":  services.SubtasksCascade,
+	"reparent": services.SubtasksReparent,
+}
+
 type DeleteTaskCommand struct {
 	TaskService services.TaskService
+	Priorities  models.PriorityScale
 }
 
 // Signature The name and signature of the console command.
@@ -38,6 +45,10 @@ func (r *DeleteTaskCommand) Extend() command.Extend {
 				Aliases: []string{"i"},
 				Usage:   "Comma-separated IDs of the tasks to delete",
 			},
+			&command.StringFlag{
+				Name:  "subtasks",
+				Usage: "What to do with subtasks of deleted tasks (cascade, reparent)",
+			},
 		},
 	}
 }
@@ -47,7 +58,7 @@ func (r *DeleteTaskCommand) Handle(ctx console.Context) (err error) {
 	taskIDs := ctx.OptionIntSlice("ids")
 
 	if len(taskIDs) == 0 {
-		tasks, err := r.TaskService.GetAllTasks(context.Background(), 0, 0, "")
+		tasks, err := r.TaskService.GetAllTasks(context.Background(), models.TaskFilter{})
 		if err != nil {
 			ctx.Error(err.Error())
 			return nil
@@ -55,8 +66,8 @@ func (r *DeleteTaskCommand) Handle(ctx console.Context) (err error) {
 
 		var choices []console.Choice
 		for _, t := range tasks {
-			priorityColor := constants.PriorityColors[t.Priority]
-			statusColor := constants.StatusColors[t.Status]
+			priorityColor := priorityLabel(r.Priorities, t.Priority)
+			statusColor := statusLabel(t.StatusLabel, t.StatusColor)
 
 			choice := console.Choice{
 				Key: color.Sprintf(
@@ -94,7 +105,34 @@ func (r *DeleteTaskCommand) Handle(ctx console.Context) (err error) {
 		}
 	}
 
-	if err := r.TaskService.DeleteTasks(context.Background(), taskIDs); err != nil {
+	policy, ok := subtaskPolicies[ctx.Option("subtasks")]
+	if !ok {
+		ctx.Error("Invalid subtasks option, expected cascade or reparent")
+		return nil
+	}
+
+	err = r.TaskService.DeleteTasks(context.Background(), taskIDs, policy)
+	if errors.Is(err, services.ErrTaskHasSubtasks) {
+		choices := []console.Choice{
+			{Key: "Delete the subtasks as well", Value: "cascade"},
+			{Key: "Move the subtasks up to the parent task", Value: "reparent"},
+			{Key: "Cancel", Value: ""},
+		}
+		answer, err := ctx.Choice("Some of these tasks have subtasks. What should happen to them?", choices, console.ChoiceOption{
+			Default: "",
+		})
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		if answer == "" {
+			ctx.Info("Task deletion cancelled.")
+			return nil
+		}
+
+		err = r.TaskService.DeleteTasks(context.Background(), taskIDs, subtaskPolicies[answer])
+	}
+	if err != nil {
 		ctx.Error(err.Error())
 		return nil
 	}
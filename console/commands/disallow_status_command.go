@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type DisallowStatusCommand struct {
+	StatusService services.StatusService
+}
+
+// Signature The name and signature of the console command.
+func (r *DisallowStatusCommand) Signature() string {
+	return "status:disallow"
+}
+
+// Description The console command description.
+func (r *DisallowStatusCommand) Description() string {
+	return "Stop tasks from moving from one status to another"
+}
+
+// Extend The console command extend.
+func (r *DisallowStatusCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "statuses",
+		Flags:    transitionFlags(),
+	}
+}
+
+// Handle Execute the console command.
+func (r *DisallowStatusCommand) Handle(ctx console.Context) (err error) {
+	from, to := ctx.Option("from"), ctx.Option("to")
+	if from == "" || to == "" {
+		ctx.Error("both --from and --to are required")
+		return nil
+	}
+
+	if err := r.StatusService.DisallowTransition(context.Background(), from, to); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success("Tasks can no longer move from " + from + " to " + to + ".")
+	return nil
+}
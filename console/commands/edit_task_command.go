@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/editor"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type EditTaskCommand struct {
+	TaskService   services.TaskService
+	StatusService services.StatusService
+	Priorities    models.PriorityScale
+}
+
+// Signature The name and signature of the console command.
+func (r *EditTaskCommand) Signature() string {
+	return "task:edit"
+}
+
+// Description The console command description.
+func (r *EditTaskCommand) Description() string {
+	return "Edit a task in $VISUAL or $EDITOR"
+}
+
+// Extend The console command extend.
+func (r *EditTaskCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "tasks",
+		Flags: []command.Flag{
+			&command.IntFlag{
+				Name:     "id",
+				Aliases:  []string{"i"},
+				Usage:    "The ID of the task to edit",
+				Required: true,
+			},
+			&command.BoolFlag{
+				Name:  "force",
+				Usage: "Start or complete the task even if it has open subtasks or blockers",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *EditTaskCommand) Handle(ctx console.Context) (err error) {
+	id := ctx.OptionInt("id")
+	task, err := r.TaskService.GetTaskByID(context.Background(), id)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	workflow, err := r.StatusService.GetWorkflow(context.Background())
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	var opts []services.UpdateOption
+	if ctx.OptionBool("force") {
+		opts = append(opts, services.Force())
+	}
+
+	content := newTaskDocument(task, workflow, r.Priorities).String(workflow, r.Priorities)
+	for {
+		edited, err := editor.Edit(fmt.Sprintf("task-%d-*.md", id), content)
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		if strings.TrimSpace(edited) == "" {
+			ctx.Info("Edit cancelled.")
+			return nil
+		}
+		if edited == content {
+			ctx.Info("No changes made.")
+			return nil
+		}
+
+		document, errs := parseTaskDocument(edited, workflow, r.Priorities)
+		if len(errs) == 0 {
+			err = r.TaskService.UpdateTask(context.Background(), id, func(t *models.Task) (*models.Task, error) {
+				document.apply(t, workflow, r.Priorities)
+				return t, nil
+			}, opts...)
+			switch {
+			case err == nil:
+				ctx.Success("Task updated successfully!")
+				return nil
+			case errors.Is(err, services.ErrOpenSubtasks) || errors.Is(err, services.ErrTaskBlocked):
+				errs = append(errs, fmt.Errorf("%w, run with --force to override", err))
+			case errors.Is(err, services.ErrTaskUpdateFailed) || errors.Is(err, services.ErrTaskNotFound):
+				ctx.Error(err.Error())
+				return nil
+			default:
+				errs = append(errs, err)
+			}
+		}
+
+		content = annotateErrors(edited, errs)
+	}
+}
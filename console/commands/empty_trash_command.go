@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type EmptyTrashCommand struct {
+	TaskService services.TaskService
+}
+
+// Signature The name and signature of the console command.
+func (r *EmptyTrashCommand) Signature() string {
+	return "trash:empty"
+}
+
+// Description The console command description.
+func (r *EmptyTrashCommand) Description() string {
+	return "Permanently delete the tasks in the trash"
+}
+
+// Extend The console command extend.
+func (r *EmptyTrashCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "trash",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:  "older-than",
+				Usage: "Only delete tasks that have been in the trash for longer than this (e.g. 30d, 2w)",
+			},
+			&command.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Do not ask for confirmation",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *EmptyTrashCommand) Handle(ctx console.Context) (err error) {
+	var olderThan time.Duration
+	question := "Permanently delete every task in the trash?"
+	if value := ctx.Option("older-than"); value != "" {
+		olderThan, err = dates.ParseDuration(value)
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		question = fmt.Sprintf("Permanently delete the tasks deleted more than %s ago?", value)
+	}
+
+	if !ctx.OptionBool("force") {
+		confirmed, err := ctx.Confirm(question, console.ConfirmOption{
+			Affirmative: "Yes",
+			Negative:    "No",
+		})
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		if !confirmed {
+			ctx.Info("The trash was left as it is.")
+			return nil
+		}
+	}
+
+	purged, err := r.TaskService.EmptyTrash(context.Background(), olderThan)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success(fmt.Sprintf("Permanently deleted %d task(s) from the trash.", purged))
+	return nil
+}
@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+const progressWidth = 30
+
+type FocusTaskCommand struct {
+	TaskService services.TaskService
+	TimeService services.TimeService
+}
+
+// Signature The name and signature of the console command.
+func (r *FocusTaskCommand) Signature() string {
+	return "task:focus"
+}
+
+// Description The console command description.
+func (r *FocusTaskCommand) Description() string {
+	return "Work on a task in pomodoros, with a countdown for each focus session and break"
+}
+
+// Extend The console command extend.
+func (r *FocusTaskCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "time",
+		Flags: []command.Flag{
+			&command.IntFlag{
+				Name:     "id",
+				Aliases:  []string{"i"},
+				Usage:    "The ID of the task to focus on",
+				Required: true,
+			},
+			&command.StringFlag{
+				Name:  "work",
+				Usage: "Length of a focus session",
+				Value: "25m",
+			},
+			&command.StringFlag{
+				Name:  "break",
+				Usage: "Length of the break between focus sessions",
+				Value: "5m",
+			},
+			&command.IntFlag{
+				Name:  "cycles",
+				Usage: "Number of focus sessions",
+				Value: 4,
+			},
+			&command.BoolFlag{
+				Name:  "force",
+				Usage: "Start the task even if it is blocked",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *FocusTaskCommand) Handle(ctx console.Context) (err error) {
+	work, err := dates.ParseDuration(ctx.Option("work"))
+	if err != nil || work <= 0 {
+		ctx.Error("Invalid work duration: " + ctx.Option("work"))
+		return nil
+	}
+	rest, err := dates.ParseDuration(ctx.Option("break"))
+	if err != nil {
+		ctx.Error("Invalid break duration: " + ctx.Option("break"))
+		return nil
+	}
+	cycles := ctx.OptionInt("cycles")
+	if cycles <= 0 {
+		ctx.Error("The number of cycles must be greater than zero")
+		return nil
+	}
+
+	id := ctx.OptionInt("id")
+	task, err := r.TaskService.GetTaskByID(context.Background(), id)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	var opts []services.UpdateOption
+	if ctx.OptionBool("force") {
+		opts = append(opts, services.Force())
+	}
+	stopped, err := r.TimeService.StartFocus(context.Background(), id, opts...)
+	if errors.Is(err, services.ErrTaskBlocked) {
+		force, confirmErr := ctx.Confirm(fmt.Sprintf("Warning: %s. Focus on it anyway?", err), console.ConfirmOption{
+			Affirmative: "Yes",
+			Negative:    "No",
+		})
+		if confirmErr != nil {
+			ctx.Error(confirmErr.Error())
+			return nil
+		}
+		if !force {
+			ctx.Info("Focus session not started.")
+			return nil
+		}
+
+		stopped, err = r.TimeService.StartFocus(context.Background(), id, services.Force())
+	}
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+	if stopped != nil {
+		ctx.Info(fmt.Sprintf("Stopped the timer on task %d (%s) after %s.", stopped.TaskID, stopped.Task, dates.FormatDuration(stopped.Duration(*stopped.EndedAt))))
+	}
+
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupted)
+
+	ctx.NewLine()
+	color.Println(color.Sprintf("<fg=blue;op=bold>Focusing on %s</> (<fg=white;op=bold>%d</>)", task.Title, task.ID))
+	ctx.NewLine()
+
+	completed := 0
+	for cycle := 1; cycle <= cycles; cycle++ {
+		pomodoro := &models.Pomodoro{TaskID: task.ID, StartedAt: time.Now(), Planned: work}
+		pomodoro.Completed = countdown(color.Sprintf("<fg=red;op=bold>Focus %d/%d</>", cycle, cycles), work, interrupted)
+		pomodoro.EndedAt = time.Now()
+		fmt.Print("\a")
+
+		if err := r.TimeService.RecordPomodoro(context.Background(), pomodoro); err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		if !pomodoro.Completed {
+			ctx.Warning(fmt.Sprintf("Focus session interrupted, recorded %s of work.", dates.FormatDuration(pomodoro.EndedAt.Sub(pomodoro.StartedAt))))
+			break
+		}
+		completed++
+		ctx.Success(fmt.Sprintf("Pomodoro %d/%d done.", cycle, cycles))
+
+		if cycle == cycles || rest <= 0 {
+			continue
+		}
+		finished := countdown(color.Sprintf("<fg=green;op=bold>Break %d/%d</>", cycle, cycles-1), rest, interrupted)
+		fmt.Print("\a")
+		if !finished {
+			ctx.Warning("Break interrupted.")
+			break
+		}
+	}
+
+	ctx.Info(fmt.Sprintf("Completed %d of %d pomodoros on task %d.", completed, cycles, task.ID))
+	return nil
+}
+
+// countdown shows the time left until duration has passed, redrawing one line every second. It returns
+// false if it was interrupted before the end.
+func countdown(label string, duration time.Duration, interrupted <-chan os.Signal) bool {
+	end := time.Now().Add(duration)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		left := time.Until(end)
+		if left < 0 {
+			left = 0
+		}
+		done := int(float64(progressWidth) * float64(duration-left) / float64(duration))
+		bar := strings.Repeat("█", done) + strings.Repeat("░", progressWidth-done)
+		fmt.Print("\r\033[2K" + color.Sprintf("%s %02d:%02d %s", label, int(left.Minutes()), int(left.Seconds())%60, bar))
+
+		if left == 0 {
+			fmt.Println()
+			return true
+		}
+
+		select {
+		case <-interrupted:
+			fmt.Println()
+			return false
+		case <-ticker.C:
+		}
+	}
+}
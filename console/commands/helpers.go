@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/recurrence"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+// parseDue parses an optional due date, returning nil when the value is empty or "none".
+func parseDue(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.EqualFold(value, "none") {
+		return nil, nil
+	}
+
+	due, err := dates.Parse(value, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &due, nil
+}
+
+// readNotes returns the notes given with --notes or read from --notes-file, where "-" reads standard input.
+// The boolean reports whether either option was given.
+func readNotes(notes, notesFile string) (string, bool, error) {
+	if notes != "" && notesFile != "" {
+		return "", false, errors.New("use either --notes or --notes-file, not both")
+	}
+	if notesFile == "" {
+		return notes, notes != "", nil
+	}
+
+	var content []byte
+	var err error
+	if notesFile == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(notesFile)
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	return strings.TrimRight(string(content), "\n"), true, nil
+}
+
+// formatDue renders an optional due date in a form that parseDue accepts.
+func formatDue(due *time.Time) string {
+	if due == nil {
+		return ""
+	}
+	return due.Local().Format(dates.Layout)
+}
+
+// buildRecurrence combines the --repeat, --until and --count options into the RRULE stored on a task.
+// An empty or "none" repeat yields no rule, which also stops an existing series.
+func buildRecurrence(repeat, until string, count int) (string, error) {
+	repeat = strings.TrimSpace(repeat)
+	if repeat == "" || strings.EqualFold(repeat, "none") {
+		return "", nil
+	}
+
+	rule, err := recurrence.Parse(repeat)
+	if err != nil {
+		return "", err
+	}
+
+	if until != "" {
+		untilAt, err := dates.Parse(until, time.Now())
+		if err != nil {
+			return "", err
+		}
+		rule.Until = &untilAt
+	}
+	if count > 0 {
+		rule.Count = count
+	}
+
+	return rule.String(), nil
+}
+
+// describeRecurrence renders a stored RRULE in a human-readable form.
+func describeRecurrence(rrule string) string {
+	rule, err := recurrence.Parse(rrule)
+	if err != nil {
+		return rrule
+	}
+	return rule.Describe()
+}
+
+// joinIDs renders task IDs as a comma-separated list.
+func joinIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// statusLabel renders a status label in the status's color.
+func statusLabel(label, statusColor string) string {
+	if label == "" {
+		label = "Unknown"
+	}
+	if statusColor == "" {
+		statusColor = "white"
+	}
+	return color.Sprintf("<fg=%s>%s</>", statusColor, label)
+}
+
+// statusChoices offers statuses as choices, valued by their IDs.
+func statusChoices(statuses []models.Status) []console.Choice {
+	choices := make([]console.Choice, len(statuses))
+	for i, status := range statuses {
+		choices[i] = console.Choice{Key: statusLabel(status.Label, status.Color), Value: strconv.Itoa(status.ID)}
+	}
+	return choices
+}
+
+// statusFilter resolves the status a listing is filtered by, or zero when none is given.
+func statusFilter(statuses services.StatusService, nameOrID string) (int, error) {
+	if nameOrID == "" {
+		return 0, nil
+	}
+	status, err := statuses.GetStatus(context.Background(), nameOrID)
+	if err != nil {
+		return 0, err
+	}
+	return status.ID, nil
+}
+
+// askReason asks why a task is moved to a status that requires a reason.
+func askReason(ctx console.Context, status *models.Status) (string, error) {
+	return ctx.Ask(fmt.Sprintf("Why is the task %s?", strings.ToLower(status.Label)), console.AskOption{
+		Prompt: "> ",
+		Validate: func(value string) error {
+			if strings.TrimSpace(value) == "" {
+				return services.ErrReasonRequired
+			}
+			return nil
+		},
+	})
+}
+
+// priorityLabel renders the label of a priority in its color.
+func priorityLabel(priorities models.PriorityScale, rank int) string {
+	return color.Sprintf("<fg=%s>%s</>", priorities.Color(rank), priorities.Label(rank))
+}
+
+// priorityName returns the name of a priority, or its rank when it is outside the scale.
+func priorityName(priorities models.PriorityScale, rank int) string {
+	if level := priorities.Level(rank); level != nil {
+		return level.Name
+	}
+	return strconv.Itoa(rank)
+}
+
+// priorityChoices offers the priority levels as choices, valued by their ranks.
+func priorityChoices(priorities models.PriorityScale) []console.Choice {
+	choices := make([]console.Choice, len(priorities.Levels))
+	for i := range priorities.Levels {
+		choices[i] = console.Choice{Key: priorityLabel(priorities, i+1), Value: strconv.Itoa(i + 1)}
+	}
+	return choices
+}
+
+// resolvePriority looks a priority given on the command line up by name or rank, or returns zero when none is given.
+func resolvePriority(priorities models.PriorityScale, nameOrRank string) (int, error) {
+	if nameOrRank == "" {
+		return 0, nil
+	}
+	rank := priorities.Resolve(nameOrRank)
+	if rank == 0 {
+		return 0, fmt.Errorf("%w: %q, expected one of: %s", services.ErrInvalidPriority, nameOrRank, strings.Join(priorities.Names(), ", "))
+	}
+	return rank, nil
+}
+
+// formatUrgency renders an urgency score with one decimal, dimmed when it is not positive.
+func formatUrgency(urgency float64) string {
+	if urgency <= 0 {
+		return color.Sprintf("<fg=gray>%.1f</>", urgency)
+	}
+	return fmt.Sprintf("%.1f", urgency)
+}
+
+// projectLabel renders a project name in the project's color.
+func projectLabel(name, projectColor string) string {
+	if projectColor == "" {
+		projectColor = "white"
+	}
+	return color.Sprintf("<fg=%s;op=bold>%s</>", projectColor, name)
+}
@@ -28,9 +28,7 @@ func (r *InitCommand) Extend() command.Extend {
 
 // Handle Execute the console command.
 func (r *InitCommand) Handle(ctx console.Context) (err error) {
-	err = database.RunMigration()
-	if err != nil {
-		ctx.Error(err.Error())
+	if err := database.RunMigration(); err != nil {
 		return err
 	}
 
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/models"
+)
+
+type ListPrioritiesCommand struct {
+	Priorities models.PriorityScale
+}
+
+// Signature The name and signature of the console command.
+func (r *ListPrioritiesCommand) Signature() string {
+	return "priority:list"
+}
+
+// Description The console command description.
+func (r *ListPrioritiesCommand) Description() string {
+	return "List the priority levels set in the config file, from the highest down"
+}
+
+// Extend The console command extend.
+func (r *ListPrioritiesCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "priorities",
+	}
+}
+
+// Handle Execute the console command.
+func (r *ListPrioritiesCommand) Handle(ctx console.Context) (err error) {
+	ctx.NewLine()
+	color.Println("<fg=blue;op=bold>Priority Scale:</>")
+	ctx.NewLine()
+
+	ctx.TwoColumnDetail(color.Sprint("<fg=cyan;op=bold>Priorities</>"), "Rank")
+	defaultRank := r.Priorities.DefaultRank()
+	for rank := len(r.Priorities.Levels); rank >= 1; rank-- {
+		name := color.Sprintf("<fg=white;op=bold>%s</> ", priorityName(r.Priorities, rank)) + priorityLabel(r.Priorities, rank)
+		if rank == defaultRank {
+			name += color.Sprint(" <fg=yellow>[default]</>")
+		}
+		ctx.TwoColumnDetail(name, strconv.Itoa(rank))
+	}
+	ctx.NewLine()
+
+	return nil
+}
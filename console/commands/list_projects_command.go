@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type ListProjectsCommand struct {
+	ProjectService services.ProjectService
+}
+
+// Signature The name and signature of the console command.
+func (r *ListProjectsCommand) Signature() string {
+	return "project:list"
+}
+
+// Description The console command description.
+func (r *ListProjectsCommand) Description() string {
+	return "List all projects with their completion counts"
+}
+
+// Extend The console command extend.
+func (r *ListProjectsCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "projects",
+		Flags: []command.Flag{
+			&command.BoolFlag{
+				Name:    "archived",
+				Aliases: []string{"a"},
+				Usage:   "Include archived projects",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *ListProjectsCommand) Handle(ctx console.Context) (err error) {
+	projects, err := r.ProjectService.GetAllProjects(context.Background(), ctx.OptionBool("archived"))
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	if len(projects) == 0 {
+		ctx.Info("No projects found.")
+		return nil
+	}
+
+	ctx.NewLine()
+	color.Println("<fg=blue;op=bold>Project List:</>")
+	ctx.NewLine()
+
+	ctx.TwoColumnDetail(color.Sprint("<fg=cyan;op=bold>Projects</>"), "Completed")
+	for _, project := range projects {
+		name := projectLabel(project.Name, project.Color) + color.Sprintf(" <fg=gray>%s</>", project.Description)
+		if project.Archived {
+			name += color.Sprint(" <fg=yellow>[archived]</>")
+		}
+		ctx.TwoColumnDetail(name, color.Sprintf("%d/%d", project.CompletedCount, project.TaskCount))
+	}
+	ctx.NewLine()
+
+	return nil
+}
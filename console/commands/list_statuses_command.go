@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type ListStatusesCommand struct {
+	StatusService services.StatusService
+}
+
+// Signature The name and signature of the console command.
+func (r *ListStatusesCommand) Signature() string {
+	return "status:list"
+}
+
+// Description The console command description.
+func (r *ListStatusesCommand) Description() string {
+	return "List the workflow statuses and the statuses each one can move to"
+}
+
+// Extend The console command extend.
+func (r *ListStatusesCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "statuses",
+	}
+}
+
+// Handle Execute the console command.
+func (r *ListStatusesCommand) Handle(ctx console.Context) (err error) {
+	workflow, err := r.StatusService.GetWorkflow(context.Background())
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.NewLine()
+	color.Println("<fg=blue;op=bold>Workflow:</>")
+	ctx.NewLine()
+
+	ctx.TwoColumnDetail(color.Sprint("<fg=cyan;op=bold>Statuses</>"), "Moves to")
+	for _, status := range workflow.Statuses {
+		name := color.Sprintf("<fg=white;op=bold>%s</> ", status.Name) + statusLabel(status.Label, status.Color) +
+			color.Sprintf(" <fg=gray>%s</>", status.Category)
+		if status.RequiresReason {
+			name += color.Sprint(" <fg=yellow>[reason]</>")
+		}
+
+		targets := workflow.Targets(status.ID)
+		names := make([]string, len(targets))
+		for i, target := range targets {
+			names[i] = target.Name
+		}
+		moves := strings.Join(names, ", ")
+		if moves == "" {
+			moves = color.Sprint("<fg=gray>none</>")
+		}
+		ctx.TwoColumnDetail(name, moves)
+	}
+	ctx.NewLine()
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type ListTagsCommand struct {
+	TagService services.TagService
+}
+
+// Signature The name and signature of the console command.
+func (r *ListTagsCommand) Signature() string {
+	return "tag:list"
+}
+
+// Description The console command description.
+func (r *ListTagsCommand) Description() string {
+	return "List all tags with the number of tasks using them"
+}
+
+// Extend The console command extend.
+func (r *ListTagsCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "tags",
+	}
+}
+
+// Handle Execute the console command.
+func (r *ListTagsCommand) Handle(ctx console.Context) (err error) {
+	tags, err := r.TagService.GetAllTags(context.Background())
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	if len(tags) == 0 {
+		ctx.Info("No tags found.")
+		return nil
+	}
+
+	ctx.NewLine()
+	color.Println("<fg=blue;op=bold>Tag List:</>")
+	ctx.NewLine()
+
+	ctx.TwoColumnDetail(color.Sprint("<fg=cyan;op=bold>Tags</>"), "Tasks")
+	for _, tag := range tags {
+		ctx.TwoColumnDetail(tag.Name, strconv.Itoa(tag.TaskCount))
+	}
+	ctx.NewLine()
+
+	return nil
+}
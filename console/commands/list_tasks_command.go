@@ -2,19 +2,25 @@ package commands
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/goravel/framework/contracts/console"
 	"github.com/goravel/framework/contracts/console/command"
 	"github.com/goravel/framework/support/color"
 
-	"github.com/kkumar-gcc/todo/constants"
+	"github.com/kkumar-gcc/todo/config"
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/markdown"
 	"github.com/kkumar-gcc/todo/models"
 	"github.com/kkumar-gcc/todo/services"
 )
 
 type ListTasksCommand struct {
-	TaskService services.TaskService
+	TaskService    services.TaskService
+	ProjectService services.ProjectService
+	StatusService  services.StatusService
+	Config         *config.Config
 }
 
 // Signature The name and signature of the console command.
@@ -35,17 +41,53 @@ func (r *ListTasksCommand) Extend() command.Extend {
 			&command.StringFlag{
 				Name:    "sort",
 				Aliases: []string{"s"},
-				Usage:   "Sort tasks by field (status or priority)",
+				Usage:   "Sort tasks by field (status, priority, due, created or urgency)",
 			},
 			&command.StringFlag{
 				Name:    "status",
 				Aliases: []string{"st"},
-				Usage:   "Filter tasks by status (pending, in_progress, completed)",
+				Usage:   "Filter tasks by status name or ID (e.g. pending, in-progress, blocked)",
 			},
 			&command.StringFlag{
 				Name:    "priority",
 				Aliases: []string{"p"},
-				Usage:   "Filter tasks by priority (low, medium, high)",
+				Usage:   "Filter tasks by priority name or rank",
+			},
+			&command.StringFlag{
+				Name:  "due-before",
+				Usage: "Only show tasks due before this date (e.g. friday, +7d, 2026-11-01)",
+			},
+			&command.BoolFlag{
+				Name:  "overdue",
+				Usage: "Only show open tasks that are past their due date",
+			},
+			&command.StringFlag{
+				Name:  "project",
+				Usage: "Only show tasks in this project (name or ID), including archived projects",
+			},
+			&command.StringSliceFlag{
+				Name:  "tag",
+				Usage: "Only show tasks with any of these tags",
+			},
+			&command.StringSliceFlag{
+				Name:  "tag-all",
+				Usage: "Only show tasks with all of these tags",
+			},
+			&command.StringSliceFlag{
+				Name:  "tag-none",
+				Usage: "Hide tasks with any of these tags",
+			},
+			&command.BoolFlag{
+				Name:  "archived",
+				Usage: "Show archived tasks instead of active ones",
+			},
+			&command.BoolFlag{
+				Name:  "waiting",
+				Usage: "Show snoozed tasks instead of actionable ones",
+			},
+			&command.StringFlag{
+				Name:  "max-estimate",
+				Usage: "Only show tasks estimated to take at most this long (e.g. 30m, 2h)",
 			},
 		},
 	}
@@ -54,7 +96,51 @@ func (r *ListTasksCommand) Extend() command.Extend {
 // Handle Execute the console command.
 func (r *ListTasksCommand) Handle(ctx console.Context) (err error) {
 	sort, status, priority := ctx.Option("sort"), ctx.Option("status"), ctx.Option("priority")
-	tasks, err := r.TaskService.GetAllTasks(context.Background(), constants.StatusMap[status], constants.PriorityMap[priority], sort)
+	filter := models.TaskFilter{
+		Sort:     sort,
+		Overdue:  ctx.OptionBool("overdue"),
+		AnyTags:  services.ParseTags(strings.Join(ctx.OptionSlice("tag"), ",")),
+		AllTags:  services.ParseTags(strings.Join(ctx.OptionSlice("tag-all"), ",")),
+		NoTags:   services.ParseTags(strings.Join(ctx.OptionSlice("tag-none"), ",")),
+		Archived: ctx.OptionBool("archived"),
+		Waiting:  ctx.OptionBool("waiting"),
+	}
+
+	if filter.Status, err = statusFilter(r.StatusService, status); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+	if filter.Priority, err = resolvePriority(r.Config.Priorities, priority); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	if dueBefore := ctx.Option("due-before"); dueBefore != "" {
+		filter.DueBefore, err = parseDue(dueBefore)
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+
+	if maxEstimate := ctx.Option("max-estimate"); maxEstimate != "" {
+		filter.MaxEstimate, err = dates.ParseDuration(maxEstimate)
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+
+	if name := ctx.Option("project"); name != "" {
+		project, err := r.ProjectService.GetProject(context.Background(), name)
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		filter.ProjectID = project.ID
+	}
+
+	tasks, err := r.TaskService.GetAllTasks(context.Background(), filter)
 	if err != nil {
 		ctx.Error(err.Error())
 		return nil
@@ -69,30 +155,116 @@ func (r *ListTasksCommand) Handle(ctx console.Context) (err error) {
 	color.Println("<fg=blue;op=bold>Task List:</>")
 	ctx.NewLine()
 
+	now := time.Now()
 	groupedTasks := r.groupTasks(tasks, sort)
 	for label, tasksGroup := range groupedTasks {
-		ctx.TwoColumnDetail(color.Sprintf("<fg=cyan;op=bold>%s</>", label), "Details")
-		for _, task := range tasksGroup {
-			idLabel := color.Sprintf("<fg=white;op=bold>%d</>", task.ID)
-			statusLabel := constants.StatusColors[task.Status]
-			priorityLabel := constants.PriorityColors[task.Priority]
-			tagsAndCreatedAt := color.Sprintf("<fg=gray>Tags: %s, Created At: %s</>", task.Tags, task.CreatedAt.Format(time.RFC822))
-			ctx.TwoColumnDetail(task.Title+" ("+idLabel+") "+tagsAndCreatedAt, statusLabel+" | "+priorityLabel)
-		}
+		ctx.TwoColumnDetail(color.Sprintf("<fg=cyan;op=bold>%s</>", markdown.Escape(label)), "Details")
+		r.printTree(ctx, tasksGroup, now)
 		ctx.NewLine()
 	}
 
 	return nil
 }
 
+// printTree prints the tasks as an indented tree, nesting subtasks under their parent when both are listed.
+func (r *ListTasksCommand) printTree(ctx console.Context, tasks []models.Task, now time.Time) {
+	listed := make(map[int]bool)
+	for _, task := range tasks {
+		listed[task.ID] = true
+	}
+
+	var roots []models.Task
+	children := make(map[int][]models.Task)
+	for _, task := range tasks {
+		if task.ParentID != nil && listed[*task.ParentID] {
+			children[*task.ParentID] = append(children[*task.ParentID], task)
+		} else {
+			roots = append(roots, task)
+		}
+	}
+
+	var walk func(task models.Task, prefix, childPrefix string)
+	walk = func(task models.Task, prefix, childPrefix string) {
+		r.printTask(ctx, task, prefix, now)
+		subtasks := children[task.ID]
+		for i, subtask := range subtasks {
+			if i == len(subtasks)-1 {
+				walk(subtask, childPrefix+"└─ ", childPrefix+"   ")
+			} else {
+				walk(subtask, childPrefix+"├─ ", childPrefix+"│  ")
+			}
+		}
+	}
+
+	for _, task := range roots {
+		walk(task, "", "")
+	}
+}
+
+func (r *ListTasksCommand) printTask(ctx console.Context, task models.Task, prefix string, now time.Time) {
+	idLabel := color.Sprintf("<fg=white;op=bold>%d</>", task.ID)
+	status := statusLabel(task.StatusLabel, task.StatusColor)
+	priority := priorityLabel(r.Config.Priorities, task.Priority)
+	tagsAndCreatedAt := color.Sprintf("<fg=gray>Tags: %s, Created At: %s</>", markdown.Escape(strings.Join(task.Tags, ", ")), task.CreatedAt.Format(time.RFC822))
+	if task.Project != "" {
+		tagsAndCreatedAt += color.Sprintf(" <fg=gray>Project: %s</>", markdown.Escape(task.Project))
+	}
+	if task.Recurrence != "" {
+		tagsAndCreatedAt += color.Sprintf(" <fg=gray>Repeats %s</>", describeRecurrence(task.Recurrence))
+	}
+	if task.StatusReason != "" {
+		tagsAndCreatedAt += color.Sprintf(" <fg=gray>Reason: %s</>", markdown.Escape(task.StatusReason))
+	}
+	if task.IsBlocked() {
+		tagsAndCreatedAt += color.Sprintf(" <fg=red>[blocked by %s]</>", joinIDs(task.BlockedBy))
+	}
+	if task.SubtaskCount > 0 {
+		tagsAndCreatedAt += color.Sprintf(" <fg=cyan>[%d/%d done]</>", task.CompletedSubtasks, task.SubtaskCount)
+	}
+	if !task.Estimate.IsZero() {
+		tagsAndCreatedAt += color.Sprintf(" <fg=gray>Estimate: %s</>", task.Estimate)
+	}
+	if task.TrackedTime > 0 {
+		tagsAndCreatedAt += color.Sprintf(" <fg=gray>Tracked: %s</>", dates.FormatDuration(task.TrackedTime))
+	}
+	if task.Tracking {
+		tagsAndCreatedAt += color.Sprint(" <fg=green>[tracking]</>")
+	}
+	if task.IsWaiting(now) {
+		tagsAndCreatedAt += color.Sprintf(" <fg=gray>[snoozed until %s]</>", task.WaitUntil.Local().Format(time.RFC822))
+	}
+	if task.Resurfaced(now) != nil {
+		tagsAndCreatedAt += color.Sprint(" <fg=magenta>[resurfaced]</>")
+	}
+	ctx.TwoColumnDetail(color.Sprintf("<fg=gray>%s</>", prefix)+r.formatTitle(task, now)+" ("+idLabel+") "+tagsAndCreatedAt, status+" | "+priority+" | "+formatUrgency(task.Urgency))
+}
+
+// formatTitle colors overdue tasks red and tasks due today yellow, appending the due date.
+func (r *ListTasksCommand) formatTitle(task models.Task, now time.Time) string {
+	title := markdown.Escape(task.Title)
+	if task.DueAt == nil {
+		return title
+	}
+
+	due := task.DueAt.Local().Format(time.RFC822)
+	switch {
+	case task.IsOverdue(now):
+		return color.Sprintf("<fg=red;op=bold>%s</> <fg=red>(overdue, due %s)</>", title, due)
+	case !task.IsDone() && dates.SameDay(now, *task.DueAt):
+		return color.Sprintf("<fg=yellow;op=bold>%s</> <fg=yellow>(due today, %s)</>", title, task.DueAt.Local().Format(time.Kitchen))
+	default:
+		return title + color.Sprintf(" <fg=gray>(due %s)</>", due)
+	}
+}
+
 func (r *ListTasksCommand) groupTasks(tasks []models.Task, sort string) map[string][]models.Task {
 	grouped := make(map[string][]models.Task)
 	for _, task := range tasks {
 		var key string
 		if sort == "priority" {
-			key = constants.PriorityLabels[task.Priority]
+			key = r.Config.Priorities.Label(task.Priority)
 		} else if sort == "status" {
-			key = constants.StatusLabels[task.Status]
+			key = task.StatusLabel
 		} else {
 			key = "Tasks"
 		}
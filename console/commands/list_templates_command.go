@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type ListTemplatesCommand struct {
+	TemplateService services.TemplateService
+	Dir             string
+}
+
+// Signature The name and signature of the console command.
+func (r *ListTemplatesCommand) Signature() string {
+	return "template:list"
+}
+
+// Description The console command description.
+func (r *ListTemplatesCommand) Description() string {
+	return "List the task templates in the templates directory"
+}
+
+// Extend The console command extend.
+func (r *ListTemplatesCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "templates",
+	}
+}
+
+// Handle Execute the console command.
+func (r *ListTemplatesCommand) Handle(ctx console.Context) (err error) {
+	templates, err := r.TemplateService.GetAllTemplates(context.Background())
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	if len(templates) == 0 {
+		ctx.Info(fmt.Sprintf("No templates found. Add YAML files to %s to create some.", r.Dir))
+		return nil
+	}
+
+	ctx.NewLine()
+	color.Println("<fg=blue;op=bold>Templates:</>")
+	ctx.NewLine()
+
+	ctx.TwoColumnDetail(color.Sprint("<fg=cyan;op=bold>Templates</>"), "Tasks")
+	for _, template := range templates {
+		name := color.Sprintf("<fg=white;op=bold>%s</>", template.Name)
+		if template.Description != "" {
+			name += " " + template.Description
+		}
+		if len(template.Variables) > 0 {
+			name += color.Sprintf(" <fg=gray>--var %s</>", strings.Join(template.Variables, " --var "))
+		}
+		ctx.TwoColumnDetail(name, fmt.Sprint(template.Count()))
+	}
+	ctx.NewLine()
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type ListTrashCommand struct {
+	TaskService services.TaskService
+}
+
+// Signature The name and signature of the console command.
+func (r *ListTrashCommand) Signature() string {
+	return "trash:list"
+}
+
+// Description The console command description.
+func (r *ListTrashCommand) Description() string {
+	return "List the deleted tasks in the trash"
+}
+
+// Extend The console command extend.
+func (r *ListTrashCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "trash",
+	}
+}
+
+// Handle Execute the console command.
+func (r *ListTrashCommand) Handle(ctx console.Context) (err error) {
+	tasks, err := r.TaskService.GetAllTasks(context.Background(), models.TaskFilter{Deleted: true, Sort: "deleted"})
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	if len(tasks) == 0 {
+		ctx.Info("The trash is empty.")
+		return nil
+	}
+
+	ctx.NewLine()
+	color.Println("<fg=blue;op=bold>Trash:</>")
+	ctx.NewLine()
+
+	ctx.TwoColumnDetail(color.Sprint("<fg=cyan;op=bold>Tasks</>"), "Deleted")
+	for _, task := range tasks {
+		title := task.Title + color.Sprintf(" (<fg=white;op=bold>%d</>) ", task.ID) + statusLabel(task.StatusLabel, task.StatusColor)
+		ctx.TwoColumnDetail(title, color.Sprintf("<fg=gray>%s</>", task.DeletedAt.Local().Format(time.RFC822)))
+	}
+	ctx.NewLine()
+	color.Println("<fg=gray>Restore tasks with trash:restore --ids, or delete them for good with trash:empty.</>")
+	ctx.NewLine()
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type LogTimeCommand struct {
+	TimeService services.TimeService
+}
+
+// Signature The name and signature of the console command.
+func (r *LogTimeCommand) Signature() string {
+	return "time:log"
+}
+
+// Description The console command description.
+func (r *LogTimeCommand) Description() string {
+	return "Log time spent on a task without running a timer"
+}
+
+// Extend The console command extend.
+func (r *LogTimeCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "time",
+		Flags: []command.Flag{
+			&command.IntFlag{
+				Name:     "id",
+				Aliases:  []string{"i"},
+				Usage:    "The ID of the task the time was spent on",
+				Required: true,
+			},
+			&command.StringFlag{
+				Name:     "duration",
+				Aliases:  []string{"d"},
+				Usage:    "How long you worked on the task (e.g. 1h30m, 45m)",
+				Required: true,
+			},
+			&command.StringFlag{
+				Name:  "date",
+				Usage: "When the work ended (e.g. yesterday 17:00, 2026-10-01), defaults to now",
+			},
+			&command.StringFlag{
+				Name:    "note",
+				Aliases: []string{"n"},
+				Usage:   "A note describing the work",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *LogTimeCommand) Handle(ctx console.Context) (err error) {
+	duration, err := dates.ParseDuration(ctx.Option("duration"))
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	now := time.Now()
+	endedAt := now
+	if date := ctx.Option("date"); date != "" {
+		endedAt, err = dates.Parse(date, now)
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		// Dates without a time resolve to the end of the day, which for today is still to come.
+		if endedAt.After(now) && dates.SameDay(now, endedAt) {
+			endedAt = now
+		}
+	}
+
+	entry, err := r.TimeService.LogTime(context.Background(), ctx.OptionInt("id"), duration, endedAt, ctx.Option("note"))
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success(fmt.Sprintf("Logged %s on task %d (%s).", dates.FormatDuration(duration), entry.TaskID, entry.Task))
+	return nil
+}
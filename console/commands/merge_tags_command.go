@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type MergeTagsCommand struct {
+	TagService services.TagService
+}
+
+// Signature The name and signature of the console command.
+func (r *MergeTagsCommand) Signature() string {
+	return "tag:merge"
+}
+
+// Description The console command description.
+func (r *MergeTagsCommand) Description() string {
+	return "Merge one or more tags into another"
+}
+
+// Extend The console command extend.
+func (r *MergeTagsCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "tags",
+		Flags: []command.Flag{
+			&command.StringSliceFlag{
+				Name:     "from",
+				Usage:    "The tags to merge, separated by commas",
+				Required: true,
+			},
+			&command.StringFlag{
+				Name:     "into",
+				Usage:    "The tag to merge them into, created if it does not exist",
+				Required: true,
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *MergeTagsCommand) Handle(ctx console.Context) (err error) {
+	from := strings.Split(strings.Join(ctx.OptionSlice("from"), ","), ",")
+	if err := r.TagService.MergeTags(context.Background(), from, ctx.Option("into")); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success("Tags merged successfully!")
+	return nil
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/database"
+)
+
+type MigrateCommand struct {
+	Migrator *database.Migrator
+}
+
+// Signature The name and signature of the console command.
+func (r *MigrateCommand) Signature() string {
+	return "migrate"
+}
+
+// Description The console command description.
+func (r *MigrateCommand) Description() string {
+	return "Run the pending database migrations"
+}
+
+// Extend The console command extend.
+func (r *MigrateCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "migrate",
+	}
+}
+
+// Handle Execute the console command.
+func (r *MigrateCommand) Handle(ctx console.Context) (err error) {
+	ran, err := r.Migrator.Migrate(context.Background())
+	var skipped []string
+	for _, migration := range ran {
+		name := fmt.Sprintf("%06d_%s", migration.Version, migration.Name)
+		if migration.Skipped {
+			ctx.TwoColumnDetail(name, color.Sprint("<fg=yellow;op=bold>SKIPPED</>"))
+			skipped = append(skipped, migration.Feature)
+			continue
+		}
+		ctx.TwoColumnDetail(name, color.Sprint("<fg=green;op=bold>DONE</>"))
+	}
+	if len(skipped) > 0 {
+		ctx.Warning(fmt.Sprintf("This build of todo has no SQLite %s support, so full-text search is unavailable. Reinstall it with -tags sqlite_fts5 and run todo migrate again to enable it.", strings.Join(slices.Compact(skipped), ", ")))
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(ran) == 0 {
+		ctx.Info("Nothing to migrate.")
+		return nil
+	}
+
+	ctx.Success("Migrations applied successfully.")
+	return nil
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type MigratePrioritiesCommand struct {
+	TaskService services.TaskService
+	Priorities  models.PriorityScale
+}
+
+// Signature The name and signature of the console command.
+func (r *MigratePrioritiesCommand) Signature() string {
+	return "priority:migrate"
+}
+
+// Description The console command description.
+func (r *MigratePrioritiesCommand) Description() string {
+	return "Move the priorities of existing tasks onto the priority scale set in the config file"
+}
+
+// Extend The console command extend.
+func (r *MigratePrioritiesCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "priorities",
+		Flags: []command.Flag{
+			&command.StringSliceFlag{
+				Name:  "from",
+				Usage: "The levels of the previous scale, from the lowest to the highest, defaults to low, medium, high",
+			},
+			&command.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Do not ask for confirmation",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *MigratePrioritiesCommand) Handle(ctx console.Context) (err error) {
+	from := models.DefaultPriorityScale()
+	if names := ctx.OptionSlice("from"); len(names) > 0 {
+		from = models.PriorityScale{}
+		for _, name := range names {
+			from.Levels = append(from.Levels, models.PriorityLevel{Name: strings.TrimSpace(name)})
+		}
+		if err := from.Validate(); err != nil {
+			ctx.Error("invalid --from: " + err.Error())
+			return nil
+		}
+	}
+
+	remap := r.Priorities.RemapFrom(from)
+	mapping := make([]string, len(from.Levels))
+	for i, level := range from.Levels {
+		mapping[i] = level.Name + " → " + priorityName(r.Priorities, remap(i+1))
+	}
+	ctx.Info("Priorities will move as follows: " + strings.Join(mapping, ", "))
+
+	if !ctx.OptionBool("force") {
+		confirmed, err := ctx.Confirm("Move the priority of every task? Run this once after changing the scale.", console.ConfirmOption{
+			Affirmative: "Yes",
+			Negative:    "No",
+		})
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		if !confirmed {
+			ctx.Info("The priorities were left as they are.")
+			return nil
+		}
+	}
+
+	remapped, err := r.TaskService.RemapPriorities(context.Background(), from)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success(fmt.Sprintf("Moved the priority of %d task(s).", len(remapped)))
+	return nil
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/database"
+)
+
+type MigrateRollbackCommand struct {
+	Migrator *database.Migrator
+}
+
+// Signature The name and signature of the console command.
+func (r *MigrateRollbackCommand) Signature() string {
+	return "migrate:rollback"
+}
+
+// Description The console command description.
+func (r *MigrateRollbackCommand) Description() string {
+	return "Roll back the most recent database migrations"
+}
+
+// Extend The console command extend.
+func (r *MigrateRollbackCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "migrate",
+		Flags: []command.Flag{
+			&command.IntFlag{
+				Name:  "step",
+				Value: 1,
+				Usage: "The number of migrations to roll back",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *MigrateRollbackCommand) Handle(ctx console.Context) (err error) {
+	step := ctx.OptionInt("step")
+	if step <= 0 {
+		return errors.New("the step option must be a positive integer")
+	}
+
+	reverted, err := r.Migrator.Rollback(context.Background(), step)
+	for _, migration := range reverted {
+		ctx.TwoColumnDetail(fmt.Sprintf("%06d_%s", migration.Version, migration.Name), color.Sprint("<fg=green;op=bold>DONE</>"))
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(reverted) == 0 {
+		ctx.Info("Nothing to roll back.")
+		return nil
+	}
+
+	ctx.Success("Migrations rolled back successfully.")
+	return nil
+}
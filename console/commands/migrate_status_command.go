@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/database"
+)
+
+type MigrateStatusCommand struct {
+	Migrator *database.Migrator
+}
+
+// Signature The name and signature of the console command.
+func (r *MigrateStatusCommand) Signature() string {
+	return "migrate:status"
+}
+
+// Description The console command description.
+func (r *MigrateStatusCommand) Description() string {
+	return "Show the status of each migration"
+}
+
+// Extend The console command extend.
+func (r *MigrateStatusCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "migrate",
+	}
+}
+
+// Handle Execute the console command.
+func (r *MigrateStatusCommand) Handle(ctx console.Context) (err error) {
+	statuses, err := r.Migrator.Status(context.Background())
+	if err != nil {
+		return err
+	}
+
+	ctx.NewLine()
+	ctx.TwoColumnDetail(color.Sprint("<fg=cyan;op=bold>Migration name</>"), color.Sprint("<fg=cyan;op=bold>Status</>"))
+	for _, status := range statuses {
+		name := fmt.Sprintf("%06d_%s", status.Version, status.Name)
+		if status.AppliedAt == nil {
+			ctx.TwoColumnDetail(name, color.Sprint("<fg=yellow;op=bold>Pending</>"))
+			continue
+		}
+		if status.Skipped {
+			ctx.TwoColumnDetail(name, color.Sprintf("<fg=gray>%s, needs %s</> <fg=yellow;op=bold>Skipped</>", status.AppliedAt.Local().Format(time.RFC822), status.Feature))
+			continue
+		}
+		ctx.TwoColumnDetail(name, color.Sprintf("<fg=gray>%s</> <fg=green;op=bold>Ran</>", status.AppliedAt.Local().Format(time.RFC822)))
+	}
+	ctx.NewLine()
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/markdown"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type NextTasksCommand struct {
+	TaskService services.TaskService
+	Priorities  models.PriorityScale
+}
+
+// Signature The name and signature of the console command.
+func (r *NextTasksCommand) Signature() string {
+	return "task:next"
+}
+
+// Description The console command description.
+func (r *NextTasksCommand) Description() string {
+	return "List the most urgent tasks that can be worked on now"
+}
+
+// Extend The console command extend.
+func (r *NextTasksCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "tasks",
+		Flags: []command.Flag{
+			&command.IntFlag{
+				Name:    "limit",
+				Aliases: []string{"l"},
+				Usage:   "The number of tasks to show",
+				Value:   5,
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *NextTasksCommand) Handle(ctx console.Context) (err error) {
+	limit := ctx.OptionInt("limit")
+	if limit < 1 {
+		ctx.Error("limit must be a positive integer")
+		return nil
+	}
+
+	tasks, err := r.TaskService.GetNextTasks(context.Background(), limit)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	if len(tasks) == 0 {
+		ctx.Info("Nothing to do, every open task is blocked or there are none.")
+		return nil
+	}
+
+	ctx.NewLine()
+	color.Println("<fg=blue;op=bold>Next Tasks:</>")
+	ctx.NewLine()
+
+	now := time.Now()
+	ctx.TwoColumnDetail(color.Sprint("<fg=cyan;op=bold>Tasks</>"), "Urgency")
+	for i, task := range tasks {
+		title := color.Sprintf("<fg=gray>%d.</> %s (<fg=white;op=bold>%d</>)", i+1, markdown.Escape(task.Title), task.ID)
+		var details []string
+		if task.DueAt != nil {
+			if task.IsOverdue(now) {
+				details = append(details, color.Sprintf("<fg=red>overdue, due %s</>", task.DueAt.Local().Format(time.RFC822)))
+			} else {
+				details = append(details, color.Sprintf("<fg=gray>due %s</>", task.DueAt.Local().Format(time.RFC822)))
+			}
+		}
+		if len(task.Tags) > 0 {
+			details = append(details, color.Sprintf("<fg=gray>tags: %s</>", markdown.Escape(strings.Join(task.Tags, ", "))))
+		}
+		if task.Resurfaced(now) != nil {
+			details = append(details, color.Sprint("<fg=magenta>resurfaced</>"))
+		}
+		if len(details) > 0 {
+			title += " " + strings.Join(details, color.Sprint("<fg=gray>,</> "))
+		}
+
+		ctx.TwoColumnDetail(title, statusLabel(task.StatusLabel, task.StatusColor)+" | "+priorityLabel(r.Priorities, task.Priority)+" | "+formatUrgency(task.Urgency))
+	}
+	ctx.NewLine()
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type RedoCommand struct {
+	UndoService services.UndoService
+}
+
+// Signature The name and signature of the console command.
+func (r *RedoCommand) Signature() string {
+	return "redo"
+}
+
+// Description The console command description.
+func (r *RedoCommand) Description() string {
+	return "Reapply task changes that were undone"
+}
+
+// Extend The console command extend.
+func (r *RedoCommand) Extend() command.Extend {
+	return command.Extend{
+		Flags: []command.Flag{
+			&command.IntFlag{
+				Name:  "step",
+				Value: 1,
+				Usage: "The number of operations to redo",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *RedoCommand) Handle(ctx console.Context) (err error) {
+	operations, err := r.UndoService.Redo(context.Background(), ctx.OptionInt("step"))
+	for _, operation := range operations {
+		ctx.TwoColumnDetail(operationLabel(operation), color.Sprint("<fg=green;op=bold>REDONE</>"))
+	}
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success("Redo completed successfully.")
+	return nil
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type RenameProjectCommand struct {
+	ProjectService services.ProjectService
+}
+
+// Signature The name and signature of the console command.
+func (r *RenameProjectCommand) Signature() string {
+	return "project:rename"
+}
+
+// Description The console command description.
+func (r *RenameProjectCommand) Description() string {
+	return "Rename a project"
+}
+
+// Extend The console command extend.
+func (r *RenameProjectCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "projects",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:     "name",
+				Aliases:  []string{"n"},
+				Usage:    "The current name or ID of the project",
+				Required: true,
+			},
+			&command.StringFlag{
+				Name:     "to",
+				Usage:    "The new name of the project",
+				Required: true,
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *RenameProjectCommand) Handle(ctx console.Context) (err error) {
+	if err := r.ProjectService.RenameProject(context.Background(), ctx.Option("name"), ctx.Option("to")); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success("Project renamed successfully!")
+	return nil
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type RenameTagCommand struct {
+	TagService services.TagService
+}
+
+// Signature The name and signature of the console command.
+func (r *RenameTagCommand) Signature() string {
+	return "tag:rename"
+}
+
+// Description The console command description.
+func (r *RenameTagCommand) Description() string {
+	return "Rename a tag on every task that uses it"
+}
+
+// Extend The console command extend.
+func (r *RenameTagCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "tags",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:     "from",
+				Usage:    "The current name of the tag",
+				Required: true,
+			},
+			&command.StringFlag{
+				Name:     "to",
+				Usage:    "The new name of the tag",
+				Required: true,
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *RenameTagCommand) Handle(ctx console.Context) (err error) {
+	if err := r.TagService.RenameTag(context.Background(), ctx.Option("from"), ctx.Option("to")); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success("Tag renamed successfully!")
+	return nil
+}
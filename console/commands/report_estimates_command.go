@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+// accurateWithin is how far off an estimate may be, as a fraction, and still count as on target.
+const accurateWithin = 0.1
+
+type ReportEstimatesCommand struct {
+	ReportService services.ReportService
+}
+
+// Signature The name and signature of the console command.
+func (r *ReportEstimatesCommand) Signature() string {
+	return "report:estimates"
+}
+
+// Description The console command description.
+func (r *ReportEstimatesCommand) Description() string {
+	return "Compare the estimates of completed tasks with the time they took, per tag"
+}
+
+// Extend The console command extend.
+func (r *ReportEstimatesCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "report",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:  "from",
+				Usage: "Only tasks completed from this date (e.g. monday, 2026-07-01), defaults to all of them",
+			},
+			&command.StringFlag{
+				Name:  "to",
+				Usage: "Only tasks completed up to this date, inclusive when given as a date, defaults to today",
+			},
+			&command.StringFlag{
+				Name:  "actual",
+				Usage: "What to compare the estimates with: tracked (the time tracked) or elapsed (from creation to completion)",
+				Value: models.ActualTracked,
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *ReportEstimatesCommand) Handle(ctx console.Context) (err error) {
+	now := time.Now()
+	options := models.EstimateReportOptions{
+		To:     dates.StartOfDay(now).AddDate(0, 0, 1),
+		Actual: ctx.Option("actual"),
+	}
+
+	if from := ctx.Option("from"); from != "" {
+		if options.From, err = reportBound(from, now, false); err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+	if to := ctx.Option("to"); to != "" {
+		if options.To, err = reportBound(to, now, true); err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+
+	report, err := r.ReportService.GetEstimateReport(context.Background(), options)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.NewLine()
+	period := "until " + report.To.Add(-time.Second).Format(time.DateOnly)
+	if !report.From.IsZero() {
+		period = report.From.Format(time.DateOnly) + " – " + report.To.Add(-time.Second).Format(time.DateOnly)
+	}
+	color.Println(color.Sprintf("<fg=blue;op=bold>Estimate Accuracy: %s, compared with %s time</>", period, report.Actual))
+	ctx.NewLine()
+
+	if report.Total.Tasks+report.Total.PointTasks == 0 {
+		ctx.Info("No estimated tasks were completed in this period.")
+		if report.Skipped > 0 {
+			ctx.Info(fmt.Sprintf("%d estimated tasks have no tracked time, try --actual elapsed.", report.Skipped))
+		}
+		return nil
+	}
+
+	for _, group := range report.Groups {
+		printEstimateAccuracy(ctx, group, "<fg=cyan;op=bold>%s</>")
+	}
+	ctx.NewLine()
+	printEstimateAccuracy(ctx, report.Total, "<fg=green;op=bold>%s</>")
+	ctx.NewLine()
+
+	if report.Total.Tasks > 0 {
+		ratio := report.Total.Ratio()
+		switch {
+		case ratio > 1+accurateWithin:
+			ctx.Warning(fmt.Sprintf("Tasks took %d%% longer than estimated, estimates are too low.", percentOff(ratio)))
+		case ratio < 1-accurateWithin:
+			ctx.Warning(fmt.Sprintf("Tasks took %d%% less time than estimated, estimates are too high.", percentOff(ratio)))
+		default:
+			ctx.Success("Estimates are on target.")
+		}
+	}
+	if report.Skipped > 0 {
+		ctx.Info(fmt.Sprintf("Skipped %d estimated tasks without tracked time.", report.Skipped))
+	}
+
+	return nil
+}
+
+// printEstimateAccuracy prints the duration and story point estimates of a group, each on its own line.
+func printEstimateAccuracy(ctx console.Context, accuracy models.EstimateAccuracy, nameFormat string) {
+	name := color.Sprintf(nameFormat, accuracy.Name)
+	if accuracy.Tasks > 0 {
+		ctx.TwoColumnDetail(
+			name+color.Sprintf(" <fg=gray>%s</>", pluralize(accuracy.Tasks, "task")),
+			fmt.Sprintf("%s estimated, %s actual ", dates.FormatDuration(accuracy.Estimated), dates.FormatDuration(accuracy.Actual))+accuracyLabel(accuracy.Ratio()),
+		)
+		name = color.Sprintf(nameFormat, "")
+	}
+	if accuracy.PointTasks > 0 {
+		ctx.TwoColumnDetail(
+			name+color.Sprintf(" <fg=gray>%s</>", pluralize(accuracy.PointTasks, "task")),
+			fmt.Sprintf("%dpt, %s actual, %s per point", accuracy.Points, dates.FormatDuration(accuracy.PointActual), dates.FormatDuration(accuracy.PerPoint())),
+		)
+	}
+}
+
+// accuracyLabel shows how far the actual effort was off the estimate, in red when it took longer.
+func accuracyLabel(ratio float64) string {
+	switch {
+	case ratio > 1+accurateWithin:
+		return color.Sprintf("<fg=red>(+%d%%)</>", percentOff(ratio))
+	case ratio < 1-accurateWithin:
+		return color.Sprintf("<fg=yellow>(-%d%%)</>", percentOff(ratio))
+	default:
+		return color.Sprint("<fg=green>(on target)</>")
+	}
+}
+
+// percentOff returns how far a ratio is from 1, in percent.
+func percentOff(ratio float64) int {
+	return int(math.Round(math.Abs(ratio-1) * 100))
+}
@@ -0,0 +1,258 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type ReportTimeCommand struct {
+	ReportService services.ReportService
+}
+
+// Signature The name and signature of the console command.
+func (r *ReportTimeCommand) Signature() string {
+	return "report:time"
+}
+
+// Description The console command description.
+func (r *ReportTimeCommand) Description() string {
+	return "Show a timesheet of the time tracked in a period"
+}
+
+// Extend The console command extend.
+func (r *ReportTimeCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "report",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:  "from",
+				Usage: "Start of the period (e.g. monday, 2026-10-01), defaults to the start of this week",
+			},
+			&command.StringFlag{
+				Name:  "to",
+				Usage: "End of the period, inclusive when given as a date (e.g. today, 2026-10-31), defaults to today",
+			},
+			&command.StringFlag{
+				Name:    "group-by",
+				Aliases: []string{"g"},
+				Usage:   "Group the entries by task, tag, project, day or week",
+				Value:   models.ReportByTask,
+			},
+			&command.StringFlag{
+				Name:  "round",
+				Usage: "Round the time of every row to a multiple of this duration (e.g. 15m)",
+			},
+			&command.StringFlag{
+				Name:  "rounding",
+				Usage: "Which way to round: up, down or nearest",
+				Value: models.RoundUp,
+			},
+			&command.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Usage:   "Output format: table, csv or json",
+				Value:   "table",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *ReportTimeCommand) Handle(ctx console.Context) (err error) {
+	now := time.Now()
+	options := models.TimeReportOptions{
+		From:     dates.StartOfDay(now).AddDate(0, 0, -(int(now.Weekday())+6)%7),
+		To:       dates.StartOfDay(now).AddDate(0, 0, 1),
+		GroupBy:  ctx.Option("group-by"),
+		Rounding: ctx.Option("rounding"),
+	}
+
+	if from := ctx.Option("from"); from != "" {
+		if options.From, err = reportBound(from, now, false); err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+	if to := ctx.Option("to"); to != "" {
+		if options.To, err = reportBound(to, now, true); err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+	if round := ctx.Option("round"); round != "" {
+		if options.Round, err = dates.ParseDuration(round); err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+
+	format := ctx.Option("format")
+	if format != "table" && format != "csv" && format != "json" {
+		ctx.Error("Invalid format, expected table, csv or json")
+		return nil
+	}
+
+	report, err := r.ReportService.GetTimeReport(context.Background(), options)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	switch format {
+	case "csv":
+		err = writeTimeReportCSV(report)
+	case "json":
+		err = writeTimeReportJSON(report)
+	default:
+		printTimeReport(ctx, report)
+	}
+	if err != nil {
+		ctx.Error(err.Error())
+	}
+
+	return nil
+}
+
+// reportBound parses the start or end of a report period. A date without a time of day covers the
+// whole day, so it starts at midnight, and as an end it runs until the following midnight.
+func reportBound(value string, now time.Time, end bool) (time.Time, error) {
+	t, err := dates.Parse(value, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	wholeDay := t.Equal(dates.StartOfDay(t)) || t.Equal(dates.EndOfDay(t))
+	if !wholeDay {
+		return t, nil
+	}
+	if end {
+		return dates.StartOfDay(t).AddDate(0, 0, 1), nil
+	}
+	return dates.StartOfDay(t), nil
+}
+
+func printTimeReport(ctx console.Context, report *models.TimeReport) {
+	ctx.NewLine()
+	title := fmt.Sprintf("Time Report: %s – %s, by %s", report.From.Format(time.DateOnly), report.To.Add(-time.Second).Format(time.DateOnly), report.GroupBy)
+	if report.Round > 0 {
+		title += fmt.Sprintf(", rounded %s to %s", report.Rounding, dates.FormatDuration(report.Round))
+	}
+	color.Println(color.Sprintf("<fg=blue;op=bold>%s</>", title))
+	ctx.NewLine()
+
+	if len(report.Groups) == 0 {
+		ctx.Info("No time was tracked in this period.")
+		return
+	}
+
+	for _, group := range report.Groups {
+		ctx.TwoColumnDetail(color.Sprintf("<fg=cyan;op=bold>%s</>", group.Name), color.Sprintf("<fg=cyan;op=bold>%s</>", dates.FormatDuration(group.Total)))
+		for _, row := range group.Rows {
+			label := "  " + row.Label
+			if report.GroupBy != models.ReportByTask {
+				label += color.Sprintf(" (<fg=white;op=bold>%d</>)", row.TaskID)
+			}
+			ctx.TwoColumnDetail(label, dates.FormatDuration(row.Duration))
+		}
+	}
+	ctx.NewLine()
+	ctx.TwoColumnDetail(color.Sprint("<fg=green;op=bold>Total</>"), color.Sprintf("<fg=green;op=bold>%s</>", dates.FormatDuration(report.Total)))
+	ctx.NewLine()
+}
+
+// writeTimeReportCSV writes one line per row, followed by the subtotal of its group and, last, the total.
+func writeTimeReportCSV(report *models.TimeReport) error {
+	writer := csv.NewWriter(os.Stdout)
+	records := [][]string{{report.GroupBy, "item", "task_id", "duration", "hours"}}
+	for _, group := range report.Groups {
+		for _, row := range group.Rows {
+			records = append(records, []string{group.Name, row.Label, strconv.Itoa(row.TaskID), clockDuration(row.Duration), decimalHours(row.Duration)})
+		}
+		records = append(records, []string{group.Name, "Subtotal", "", clockDuration(group.Total), decimalHours(group.Total)})
+	}
+	records = append(records, []string{"Total", "", "", clockDuration(report.Total), decimalHours(report.Total)})
+
+	return writer.WriteAll(records)
+}
+
+type timeReportJSON struct {
+	From     time.Time             `json:"from"`
+	To       time.Time             `json:"to"`
+	GroupBy  string                `json:"group_by"`
+	Round    int                   `json:"round_minutes"`
+	Rounding string                `json:"rounding"`
+	Groups   []timeReportGroupJSON `json:"groups"`
+	Minutes  int                   `json:"total_minutes"`
+	Hours    float64               `json:"total_hours"`
+}
+
+type timeReportGroupJSON struct {
+	Name    string              `json:"name"`
+	Rows    []timeReportRowJSON `json:"rows"`
+	Minutes int                 `json:"total_minutes"`
+	Hours   float64             `json:"total_hours"`
+}
+
+type timeReportRowJSON struct {
+	Label   string  `json:"label"`
+	TaskID  int     `json:"task_id"`
+	Minutes int     `json:"minutes"`
+	Hours   float64 `json:"hours"`
+}
+
+func writeTimeReportJSON(report *models.TimeReport) error {
+	output := timeReportJSON{
+		From:     report.From,
+		To:       report.To,
+		GroupBy:  report.GroupBy,
+		Round:    minutes(report.Round),
+		Rounding: report.Rounding,
+		Groups:   []timeReportGroupJSON{},
+		Minutes:  minutes(report.Total),
+		Hours:    hours(report.Total),
+	}
+	for _, group := range report.Groups {
+		groupJSON := timeReportGroupJSON{Name: group.Name, Minutes: minutes(group.Total), Hours: hours(group.Total)}
+		for _, row := range group.Rows {
+			groupJSON.Rows = append(groupJSON.Rows, timeReportRowJSON{Label: row.Label, TaskID: row.TaskID, Minutes: minutes(row.Duration), Hours: hours(row.Duration)})
+		}
+		output.Groups = append(output.Groups, groupJSON)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+func minutes(d time.Duration) int {
+	return int(d.Round(time.Minute) / time.Minute)
+}
+
+// hours renders a duration in hours with two decimals, the way invoices usually show it.
+func hours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
+
+// clockDuration renders a duration as hours and minutes, e.g. "1:05".
+func clockDuration(d time.Duration) string {
+	total := minutes(d)
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
+
+func decimalHours(d time.Duration) string {
+	return strconv.FormatFloat(hours(d), 'f', 2, 64)
+}
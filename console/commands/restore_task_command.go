@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type RestoreTaskCommand struct {
+	TaskService services.TaskService
+}
+
+// Signature The name and signature of the console command.
+func (r *RestoreTaskCommand) Signature() string {
+	return "trash:restore"
+}
+
+// Description The console command description.
+func (r *RestoreTaskCommand) Description() string {
+	return "Restore deleted tasks from the trash"
+}
+
+// Extend The console command extend.
+func (r *RestoreTaskCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "trash",
+		Flags: []command.Flag{
+			&command.IntSliceFlag{
+				Name:     "ids",
+				Aliases:  []string{"i"},
+				Usage:    "Comma-separated IDs of the tasks to restore",
+				Required: true,
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *RestoreTaskCommand) Handle(ctx console.Context) (err error) {
+	restored, err := r.TaskService.RestoreTasks(context.Background(), ctx.OptionIntSlice("ids"))
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success(fmt.Sprintf("Restored task IDs: %v", restored))
+	return nil
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/markdown"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type SearchTasksCommand struct {
+	TaskService   services.TaskService
+	StatusService services.StatusService
+	Priorities    models.PriorityScale
+}
+
+// Signature The name and signature of the console command.
+func (r *SearchTasksCommand) Signature() string {
+	return "task:search"
+}
+
+// Description The console command description.
+func (r *SearchTasksCommand) Description() string {
+	return "Search task titles, tags and notes"
+}
+
+// Extend The console command extend.
+func (r *SearchTasksCommand) Extend() command.Extend {
+	return command.Extend{
+		Category:  "tasks",
+		ArgsUsage: ` <query> (e.g. "release notes", deploy*, bug AND NOT ui, title:report)`,
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:    "status",
+				Aliases: []string{"st"},
+				Usage:   "Filter tasks by status name or ID (e.g. pending, in-progress, blocked)",
+			},
+			&command.StringFlag{
+				Name:    "priority",
+				Aliases: []string{"p"},
+				Usage:   "Filter tasks by priority name or rank",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *SearchTasksCommand) Handle(ctx console.Context) (err error) {
+	filter := models.TaskFilter{
+		WithArchived: true,
+		WithWaiting:  true,
+	}
+
+	if filter.Status, err = statusFilter(r.StatusService, ctx.Option("status")); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+	if filter.Priority, err = resolvePriority(r.Priorities, ctx.Option("priority")); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	results, err := r.TaskService.SearchTasks(context.Background(), strings.Join(ctx.Arguments(), " "), filter)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	if len(results) == 0 {
+		ctx.Info("No tasks found matching the given query.")
+		return nil
+	}
+
+	ctx.NewLine()
+	color.Println("<fg=blue;op=bold>Search Results:</>")
+	ctx.NewLine()
+
+	for _, result := range results {
+		title := markdown.Escape(result.Title) + color.Sprintf(" (<fg=white;op=bold>%d</>)", result.ID)
+		if result.ArchivedAt != nil {
+			title += color.Sprint(" <fg=gray>[archived]</>")
+		}
+		ctx.TwoColumnDetail(title, statusLabel(result.StatusLabel, result.StatusColor)+" | "+priorityLabel(r.Priorities, result.Priority))
+		color.Println("  " + highlight(result.Snippet))
+	}
+	ctx.NewLine()
+
+	return nil
+}
+
+// highlight renders the matches marked in a search snippet in bold yellow on a gray line.
+func highlight(snippet string) string {
+	snippet = markdown.Escape(strings.Join(strings.Fields(snippet), " "))
+	snippet = strings.ReplaceAll(snippet, models.HighlightStart, "</><fg=yellow;op=bold>")
+	snippet = strings.ReplaceAll(snippet, models.HighlightEnd, "</><fg=gray>")
+	return "<fg=gray>" + snippet + "</>"
+}
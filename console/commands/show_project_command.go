@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type ShowProjectCommand struct {
+	ProjectService services.ProjectService
+	TaskService    services.TaskService
+	Priorities     models.PriorityScale
+}
+
+// Signature The name and signature of the console command.
+func (r *ShowProjectCommand) Signature() string {
+	return "project:show"
+}
+
+// Description The console command description.
+func (r *ShowProjectCommand) Description() string {
+	return "Show a project and its tasks"
+}
+
+// Extend The console command extend.
+func (r *ShowProjectCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "projects",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:     "name",
+				Aliases:  []string{"n"},
+				Usage:    "The name or ID of the project",
+				Required: true,
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *ShowProjectCommand) Handle(ctx console.Context) (err error) {
+	project, err := r.ProjectService.GetProject(context.Background(), ctx.Option("name"))
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	tasks, err := r.TaskService.GetAllTasks(context.Background(), models.TaskFilter{ProjectID: project.ID})
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.NewLine()
+	color.Println(projectLabel(project.Name, project.Color))
+	if project.Description != "" {
+		color.Println(color.Sprintf("<fg=gray>%s</>", project.Description))
+	}
+	ctx.NewLine()
+
+	ctx.TwoColumnDetail("ID", strconv.Itoa(project.ID))
+	ctx.TwoColumnDetail("Completed", color.Sprintf("%d/%d", project.CompletedCount, project.TaskCount))
+	ctx.TwoColumnDetail("Archived", strconv.FormatBool(project.Archived))
+	ctx.TwoColumnDetail("Created At", project.CreatedAt.Local().Format(time.RFC822))
+	ctx.NewLine()
+
+	if len(tasks) == 0 {
+		ctx.Info("This project has no tasks yet.")
+		return nil
+	}
+
+	ctx.TwoColumnDetail(color.Sprint("<fg=cyan;op=bold>Tasks</>"), "Details")
+	for _, task := range tasks {
+		idLabel := color.Sprintf("<fg=white;op=bold>%d</>", task.ID)
+		ctx.TwoColumnDetail(task.Title+" ("+idLabel+")", statusLabel(task.StatusLabel, task.StatusColor)+" | "+priorityLabel(r.Priorities, task.Priority))
+	}
+	ctx.NewLine()
+
+	return nil
+}
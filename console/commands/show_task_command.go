@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/markdown"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type ShowTaskCommand struct {
+	TaskService services.TaskService
+	TimeService services.TimeService
+	Priorities  models.PriorityScale
+}
+
+// Signature The name and signature of the console command.
+func (r *ShowTaskCommand) Signature() string {
+	return "task:show"
+}
+
+// Description The console command description.
+func (r *ShowTaskCommand) Description() string {
+	return "Show every detail of a task, including its notes"
+}
+
+// Extend The console command extend.
+func (r *ShowTaskCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "tasks",
+		Flags: []command.Flag{
+			&command.IntFlag{
+				Name:     "id",
+				Aliases:  []string{"i"},
+				Usage:    "The ID of the task to show",
+				Required: true,
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *ShowTaskCommand) Handle(ctx console.Context) (err error) {
+	task, err := r.TaskService.GetTaskByID(context.Background(), ctx.OptionInt("id"))
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.NewLine()
+	color.Println(color.Sprintf("<fg=blue;op=bold>%s</>", task.Title))
+	ctx.NewLine()
+
+	ctx.TwoColumnDetail("ID", strconv.Itoa(task.ID))
+	ctx.TwoColumnDetail("Status", statusLabel(task.StatusLabel, task.StatusColor))
+	if task.StatusReason != "" {
+		ctx.TwoColumnDetail("Reason", task.StatusReason)
+	}
+	ctx.TwoColumnDetail("Priority", priorityLabel(r.Priorities, task.Priority))
+	if !task.IsDone() {
+		ctx.TwoColumnDetail("Urgency", formatUrgency(task.Urgency))
+	}
+	if len(task.Tags) > 0 {
+		ctx.TwoColumnDetail("Tags", strings.Join(task.Tags, ", "))
+	}
+	if task.Project != "" {
+		ctx.TwoColumnDetail("Project", task.Project)
+	}
+	if task.ParentID != nil {
+		ctx.TwoColumnDetail("Parent", strconv.Itoa(*task.ParentID))
+	}
+	if task.SubtaskCount > 0 {
+		ctx.TwoColumnDetail("Subtasks", color.Sprintf("%d/%d done", task.CompletedSubtasks, task.SubtaskCount))
+	}
+	if task.DueAt != nil {
+		due := task.DueAt.Local().Format(time.RFC822)
+		if task.IsOverdue(time.Now()) {
+			due = color.Sprintf("<fg=red;op=bold>%s (overdue)</>", due)
+		}
+		ctx.TwoColumnDetail("Due", due)
+	}
+	if task.IsWaiting(time.Now()) {
+		ctx.TwoColumnDetail("Snoozed Until", task.WaitUntil.Local().Format(time.RFC822))
+	}
+	if resurfacedAt := task.Resurfaced(time.Now()); resurfacedAt != nil {
+		ctx.TwoColumnDetail("Resurfaced At", color.Sprintf("<fg=magenta>%s</>", resurfacedAt.Local().Format(time.RFC822)))
+	}
+	if task.Recurrence != "" {
+		ctx.TwoColumnDetail("Repeats", describeRecurrence(task.Recurrence))
+	}
+	if task.IsBlocked() {
+		ctx.TwoColumnDetail("Blocked By", color.Sprintf("<fg=red>%s</>", joinIDs(task.BlockedBy)))
+	}
+	ctx.TwoColumnDetail("Created At", task.CreatedAt.Local().Format(time.RFC822))
+	if task.CompletedAt != nil {
+		ctx.TwoColumnDetail("Completed At", task.CompletedAt.Local().Format(time.RFC822))
+	}
+	if !task.Estimate.IsZero() {
+		ctx.TwoColumnDetail("Estimate", task.Estimate.String())
+	}
+	if task.TrackedTime > 0 {
+		tracked := dates.FormatDuration(task.TrackedTime)
+		if task.Tracking {
+			tracked += color.Sprint(" <fg=green>(tracking)</>")
+		}
+		ctx.TwoColumnDetail("Tracked", tracked)
+	}
+	pomodoros, err := r.TimeService.CountPomodoros(context.Background(), task.ID)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+	if pomodoros.Completed+pomodoros.Interrupted > 0 {
+		ctx.TwoColumnDetail("Pomodoros", fmt.Sprintf("%d completed, %d interrupted", pomodoros.Completed, pomodoros.Interrupted))
+	}
+	ctx.NewLine()
+
+	entries, err := r.TimeService.GetTimeEntries(context.Background(), task.ID)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+	if len(entries) > 0 {
+		color.Println("<fg=cyan;op=bold>Time Entries</>")
+		ctx.NewLine()
+		now := time.Now()
+		for _, entry := range entries {
+			label := entry.StartedAt.Local().Format(time.RFC822)
+			if entry.Note != "" {
+				label += color.Sprintf(" <fg=gray>%s</>", entry.Note)
+			}
+			duration := dates.FormatDuration(entry.Duration(now))
+			if entry.IsRunning() {
+				duration = color.Sprintf("<fg=green>%s (running)</>", duration)
+			}
+			ctx.TwoColumnDetail(label, duration)
+		}
+		ctx.NewLine()
+	}
+
+	if strings.TrimSpace(task.Notes) != "" {
+		color.Println("<fg=cyan;op=bold>Notes</>")
+		ctx.NewLine()
+		color.Println(markdown.Render(task.Notes))
+		ctx.NewLine()
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type SnoozeTaskCommand struct {
+	TaskService services.TaskService
+}
+
+// Signature The name and signature of the console command.
+func (r *SnoozeTaskCommand) Signature() string {
+	return "task:snooze"
+}
+
+// Description The console command description.
+func (r *SnoozeTaskCommand) Description() string {
+	return "Hide a task from the task list until it becomes actionable"
+}
+
+// Extend The console command extend.
+func (r *SnoozeTaskCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "tasks",
+		Flags: []command.Flag{
+			&command.IntFlag{
+				Name:     "id",
+				Aliases:  []string{"i"},
+				Usage:    "The ID of the task to snooze",
+				Required: true,
+			},
+			&command.StringFlag{
+				Name:     "until",
+				Aliases:  []string{"u"},
+				Usage:    "When the task comes back (e.g. \"next monday\", +3d, 2026-11-01 09:00), or \"none\" to wake it now",
+				Required: true,
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *SnoozeTaskCommand) Handle(ctx console.Context) (err error) {
+	id, value := ctx.OptionInt("id"), strings.TrimSpace(ctx.Option("until"))
+
+	// A day without a time of day wakes the task when that day starts.
+	var until *time.Time
+	if !strings.EqualFold(value, "none") {
+		wakeAt, err := reportBound(value, time.Now(), false)
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		until = &wakeAt
+	}
+
+	if err := r.TaskService.SnoozeTask(context.Background(), id, until); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	if until == nil {
+		ctx.Success(fmt.Sprintf("Task %d is no longer snoozed.", id))
+		return nil
+	}
+	ctx.Success(fmt.Sprintf("Task %d is snoozed until %s.", id, until.Format(time.RFC822)))
+	return nil
+}
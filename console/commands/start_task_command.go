@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type StartTaskCommand struct {
+	TimeService services.TimeService
+}
+
+// Signature The name and signature of the console command.
+func (r *StartTaskCommand) Signature() string {
+	return "task:start"
+}
+
+// Description The console command description.
+func (r *StartTaskCommand) Description() string {
+	return "Start tracking time on a task, stopping any other running timer"
+}
+
+// Extend The console command extend.
+func (r *StartTaskCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "time",
+		Flags: []command.Flag{
+			&command.IntFlag{
+				Name:     "id",
+				Aliases:  []string{"i"},
+				Usage:    "The ID of the task to work on",
+				Required: true,
+			},
+			&command.BoolFlag{
+				Name:  "force",
+				Usage: "Start the task even if it is blocked",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *StartTaskCommand) Handle(ctx console.Context) (err error) {
+	id := ctx.OptionInt("id")
+
+	var opts []services.UpdateOption
+	if ctx.OptionBool("force") {
+		opts = append(opts, services.Force())
+	}
+
+	stopped, err := r.TimeService.StartTimer(context.Background(), id, opts...)
+	if errors.Is(err, services.ErrTaskBlocked) {
+		force, confirmErr := ctx.Confirm(fmt.Sprintf("Warning: %s. Start anyway?", err), console.ConfirmOption{
+			Affirmative: "Yes",
+			Negative:    "No",
+		})
+		if confirmErr != nil {
+			ctx.Error(confirmErr.Error())
+			return nil
+		}
+		if !force {
+			ctx.Info("Timer not started.")
+			return nil
+		}
+
+		stopped, err = r.TimeService.StartTimer(context.Background(), id, services.Force())
+	}
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	if stopped != nil {
+		ctx.Info(fmt.Sprintf("Stopped the timer on task %d (%s) after %s.", stopped.TaskID, stopped.Task, dates.FormatDuration(stopped.Duration(*stopped.EndedAt))))
+	}
+	ctx.Success(fmt.Sprintf("Started tracking time on task %d.", id))
+	return nil
+}
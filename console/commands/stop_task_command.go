@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type StopTaskCommand struct {
+	TimeService services.TimeService
+}
+
+// Signature The name and signature of the console command.
+func (r *StopTaskCommand) Signature() string {
+	return "task:stop"
+}
+
+// Description The console command description.
+func (r *StopTaskCommand) Description() string {
+	return "Stop the running timer"
+}
+
+// Extend The console command extend.
+func (r *StopTaskCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "time",
+	}
+}
+
+// Handle Execute the console command.
+func (r *StopTaskCommand) Handle(ctx console.Context) (err error) {
+	stopped, err := r.TimeService.StopTimer(context.Background())
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success(fmt.Sprintf("Stopped the timer on task %d (%s) after %s.", stopped.TaskID, stopped.Task, dates.FormatDuration(stopped.Duration(*stopped.EndedAt))))
+	return nil
+}
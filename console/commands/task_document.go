@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+const (
+	frontMatterDelimiter = "---"
+	errorCommentPrefix   = "# error: "
+)
+
+var errMissingFrontMatter = errors.New("the document must start with a --- delimited front matter block")
+
+// taskDocument is the editable form of a task: YAML front matter for the fields and a Markdown body for the notes.
+type taskDocument struct {
+	Title    string   `yaml:"title"`
+	Status   string   `yaml:"status"`
+	Reason   string   `yaml:"reason"`
+	Priority string   `yaml:"priority"`
+	Tags     []string `yaml:"tags,flow"`
+	Due      string   `yaml:"due"`
+	Estimate string   `yaml:"estimate"`
+	Notes    string   `yaml:"-"`
+}
+
+// newTaskDocument builds the editable document for a task.
+func newTaskDocument(task *models.Task, workflow *models.Workflow, priorities models.PriorityScale) *taskDocument {
+	var status string
+	if current := workflow.Status(task.Status); current != nil {
+		status = current.Name
+	}
+
+	return &taskDocument{
+		Title:    task.Title,
+		Status:   status,
+		Reason:   task.StatusReason,
+		Priority: priorityName(priorities, task.Priority),
+		Tags:     task.Tags,
+		Due:      formatDue(task.DueAt),
+		Estimate: task.Estimate.String(),
+		Notes:    task.Notes,
+	}
+}
+
+// String renders the document with its front matter and notes body, listing the statuses of the workflow
+// and the priority levels.
+func (d *taskDocument) String(workflow *models.Workflow, priorities models.PriorityScale) string {
+	var front bytes.Buffer
+	encoder := yaml.NewEncoder(&front)
+	encoder.SetIndent(2)
+	_ = encoder.Encode(d)
+
+	var b strings.Builder
+	b.WriteString(frontMatterDelimiter + "\n")
+	b.WriteString("# Edit the fields and notes below, then save and close the editor.\n")
+	b.WriteString("# Delete everything to cancel.\n")
+	fmt.Fprintf(&b, "# status: %s\n", strings.Join(workflow.Names(), ", "))
+	b.WriteString("# reason: why the task has its status, required for some statuses such as cancelled\n")
+	fmt.Fprintf(&b, "# priority: %s\n", strings.Join(priorities.Names(), ", "))
+	b.WriteString("# due: e.g. tomorrow, next fri, +3d, 2026-11-01 17:00, or empty for none\n")
+	b.WriteString("# estimate: e.g. 30m, 2h, 3pt, or empty for none\n")
+	b.WriteString(front.String())
+	b.WriteString(frontMatterDelimiter + "\n")
+	if d.Notes != "" {
+		b.WriteString(d.Notes + "\n")
+	}
+	return b.String()
+}
+
+// parseTaskDocument reads a document back, returning every problem found so they can be shown together.
+func parseTaskDocument(content string, workflow *models.Workflow, priorities models.PriorityScale) (*taskDocument, []error) {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != frontMatterDelimiter {
+		return nil, []error{errMissingFrontMatter}
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == frontMatterDelimiter {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return nil, []error{errMissingFrontMatter}
+	}
+
+	var document taskDocument
+	decoder := yaml.NewDecoder(strings.NewReader(strings.Join(lines[1:end], "\n")))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&document); err != nil && !errors.Is(err, io.EOF) {
+		return nil, []error{err}
+	}
+	document.Notes = strings.Trim(strings.Join(lines[end+1:], "\n"), "\n")
+
+	var errs []error
+	if strings.TrimSpace(document.Title) == "" {
+		errs = append(errs, services.ErrEmptyTitle)
+	}
+	if workflow.StatusByName(document.Status) == nil {
+		errs = append(errs, fmt.Errorf("status must be one of: %s", strings.Join(workflow.Names(), ", ")))
+	}
+	if priorities.Rank(document.Priority) == 0 {
+		errs = append(errs, fmt.Errorf("priority must be one of: %s", strings.Join(priorities.Names(), ", ")))
+	}
+	if _, err := parseDue(document.Due); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := models.ParseEstimate(document.Estimate); err != nil {
+		errs = append(errs, err)
+	}
+
+	return &document, errs
+}
+
+// apply copies the document's fields onto the task. The document must have been parsed without errors.
+func (d *taskDocument) apply(task *models.Task, workflow *models.Workflow, priorities models.PriorityScale) {
+	task.Title = strings.TrimSpace(d.Title)
+	task.Status = workflow.StatusByName(d.Status).ID
+	task.StatusReason = d.Reason
+	task.Priority = priorities.Rank(d.Priority)
+	task.Tags = services.NormalizeTags(d.Tags)
+	task.DueAt, _ = parseDue(d.Due)
+	task.Estimate, _ = models.ParseEstimate(d.Estimate)
+	task.Notes = d.Notes
+}
+
+// annotateErrors adds the errors as comments at the top of the front matter, replacing those from a previous attempt.
+func annotateErrors(content string, errs []error) string {
+	var kept []string
+	for _, line := range strings.Split(content, "\n") {
+		if !strings.HasPrefix(line, errorCommentPrefix) {
+			kept = append(kept, line)
+		}
+	}
+
+	comments := make([]string, len(errs))
+	for i, err := range errs {
+		comments[i] = errorCommentPrefix + strings.ReplaceAll(err.Error(), "\n", " ")
+	}
+
+	if len(kept) > 0 && strings.TrimSpace(kept[0]) == frontMatterDelimiter {
+		return strings.Join(append(append([]string{kept[0]}, comments...), kept[1:]...), "\n")
+	}
+	return strings.Join(append(comments, kept...), "\n")
+}
@@ -0,0 +1,201 @@
+package commands
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+var eventColors = map[string]string{
+	models.EventCreated:  "green",
+	models.EventUpdated:  "yellow",
+	models.EventDeleted:  "red",
+	models.EventRestored: "cyan",
+	models.EventPurged:   "red",
+}
+
+var fieldLabels = map[string]string{
+	"due_at":        "due",
+	"completed_at":  "completed",
+	"archived_at":   "archived",
+	"parent_id":     "parent",
+	"project_id":    "project",
+	"depends_on":    "depends on",
+	"status_reason": "reason",
+	"wait_until":    "snoozed until",
+	"resurfaced_at": "resurfaced",
+}
+
+type TaskHistoryCommand struct {
+	HistoryService services.HistoryService
+	StatusService  services.StatusService
+	Priorities     models.PriorityScale
+}
+
+// Signature The name and signature of the console command.
+func (r *TaskHistoryCommand) Signature() string {
+	return "task:history"
+}
+
+// Description The console command description.
+func (r *TaskHistoryCommand) Description() string {
+	return "Show the change history of a task, or recent activity across all tasks"
+}
+
+// Extend The console command extend.
+func (r *TaskHistoryCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "tasks",
+		Flags: []command.Flag{
+			&command.IntFlag{
+				Name:    "id",
+				Aliases: []string{"i"},
+				Usage:   "The ID of the task, including deleted tasks",
+			},
+			&command.StringFlag{
+				Name:  "since",
+				Usage: "Show activity across all tasks since this long ago or this date (e.g. 7d, 12h, yesterday)",
+				Value: "7d",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *TaskHistoryCommand) Handle(ctx console.Context) (err error) {
+	var events []models.TaskEvent
+	var heading string
+	id := ctx.OptionInt("id")
+	if id != 0 {
+		events, err = r.HistoryService.GetTaskHistory(context.Background(), id)
+		heading = color.Sprintf("<fg=blue;op=bold>History of task %d:</>", id)
+	} else {
+		since, parseErr := parseSince(ctx.Option("since"), time.Now())
+		if parseErr != nil {
+			ctx.Error(parseErr.Error())
+			return nil
+		}
+		events, err = r.HistoryService.GetActivity(context.Background(), since)
+		heading = color.Sprintf("<fg=blue;op=bold>Activity since %s:</>", since.Format(time.RFC822))
+	}
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	if len(events) == 0 {
+		ctx.Info("No history found.")
+		return nil
+	}
+
+	workflow, err := r.StatusService.GetWorkflow(context.Background())
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.NewLine()
+	color.Println(heading)
+	ctx.NewLine()
+
+	for _, event := range events {
+		label := color.Sprintf("<fg=%s;op=bold>%s</>", eventColors[event.Type], event.Type)
+		if id == 0 {
+			label += " " + event.Title + color.Sprintf(" (<fg=white;op=bold>%d</>)", event.TaskID)
+		}
+		when := event.CreatedAt.Local().Format(time.RFC822)
+		if event.Actor != "" {
+			when += " by " + event.Actor
+		}
+		ctx.TwoColumnDetail(label, color.Sprintf("<fg=gray>%s</>", when))
+
+		if event.Type == models.EventDeleted || event.Type == models.EventPurged {
+			continue
+		}
+		for _, change := range event.Changes {
+			color.Println("    " + formatChange(change, event.Type, workflow, r.Priorities))
+		}
+	}
+	ctx.NewLine()
+
+	return nil
+}
+
+// parseSince reads either a duration back from now, such as "7d", or a date such as "yesterday".
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if duration, err := dates.ParseDuration(value); err == nil {
+		return now.Add(-duration), nil
+	}
+	return dates.Parse(value, now)
+}
+
+// formatChange renders a field change as "field: old → new", or "field: new" when the task was created.
+func formatChange(change models.FieldChange, eventType string, workflow *models.Workflow, priorities models.PriorityScale) string {
+	label, ok := fieldLabels[change.Field]
+	if !ok {
+		label = change.Field
+	}
+
+	newValue := color.Sprintf("<fg=green>%s</>", formatFieldValue(change.Field, change.New, workflow, priorities))
+	if eventType == models.EventCreated {
+		return color.Sprintf("<fg=gray>%s:</> ", label) + newValue
+	}
+	oldValue := color.Sprintf("<fg=red>%s</>", formatFieldValue(change.Field, change.Old, workflow, priorities))
+	return color.Sprintf("<fg=gray>%s:</> ", label) + oldValue + " → " + newValue
+}
+
+// formatFieldValue renders a stored field value in the same form the other commands show it.
+func formatFieldValue(field, value string, workflow *models.Workflow, priorities models.PriorityScale) string {
+	if value == "" {
+		return "none"
+	}
+
+	switch field {
+	case "status", "priority":
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return value
+		}
+		if field == "status" {
+			if status := workflow.Status(number); status != nil {
+				return status.Label
+			}
+			return value
+		}
+		return priorities.Label(number)
+	case "due_at", "completed_at", "archived_at", "wait_until", "resurfaced_at":
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t.Local().Format(time.RFC822)
+		}
+	case "recurrence":
+		return describeRecurrence(value)
+	case "parent_id", "project_id", "depends_on":
+		return "#" + value
+	case "notes":
+		line, _, _ := strings.Cut(value, "\n")
+		if len(line) > 40 || strings.Contains(value, "\n") {
+			line = strings.TrimSpace(truncate(line, 40)) + "…"
+		}
+		return line
+	}
+
+	return value
+}
+
+// truncate shortens s to at most n runes.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type UnarchiveTaskCommand struct {
+	TaskService services.TaskService
+}
+
+// Signature The name and signature of the console command.
+func (r *UnarchiveTaskCommand) Signature() string {
+	return "task:unarchive"
+}
+
+// Description The console command description.
+func (r *UnarchiveTaskCommand) Description() string {
+	return "Return archived tasks to the active list"
+}
+
+// Extend The console command extend.
+func (r *UnarchiveTaskCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "tasks",
+		Flags: []command.Flag{
+			&command.IntSliceFlag{
+				Name:     "ids",
+				Aliases:  []string{"i"},
+				Usage:    "Comma-separated IDs of the archived tasks to unarchive",
+				Required: true,
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *UnarchiveTaskCommand) Handle(ctx console.Context) (err error) {
+	unarchived, err := r.TaskService.UnarchiveTasks(context.Background(), ctx.OptionIntSlice("ids"))
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success(fmt.Sprintf("Unarchived task IDs: %s", joinIDs(unarchived)))
+	return nil
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type UndependTaskCommand struct {
+	TaskService services.TaskService
+}
+
+// Signature The name and signature of the console command.
+func (r *UndependTaskCommand) Signature() string {
+	return "task:undepend"
+}
+
+// Description The console command description.
+func (r *UndependTaskCommand) Description() string {
+	return "Remove dependencies from a task"
+}
+
+// Extend The console command extend.
+func (r *UndependTaskCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "tasks",
+		Flags: []command.Flag{
+			&command.IntFlag{
+				Name:     "id",
+				Aliases:  []string{"i"},
+				Usage:    "The ID of the blocked task",
+				Required: true,
+			},
+			&command.IntSliceFlag{
+				Name:     "on",
+				Usage:    "Comma-separated IDs of the tasks it should no longer depend on",
+				Required: true,
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *UndependTaskCommand) Handle(ctx console.Context) (err error) {
+	id, dependsOn := ctx.OptionInt("id"), ctx.OptionIntSlice("on")
+
+	if err := r.TaskService.RemoveDependencies(context.Background(), id, dependsOn); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success(fmt.Sprintf("Task %d no longer depends on task IDs: %v", id, dependsOn))
+	return nil
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/support/color"
+
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/services"
+)
+
+type UndoCommand struct {
+	UndoService services.UndoService
+}
+
+// Signature The name and signature of the console command.
+func (r *UndoCommand) Signature() string {
+	return "undo"
+}
+
+// Description The console command description.
+func (r *UndoCommand) Description() string {
+	return "Undo the most recent task changes"
+}
+
+// Extend The console command extend.
+func (r *UndoCommand) Extend() command.Extend {
+	return command.Extend{
+		Flags: []command.Flag{
+			&command.IntFlag{
+				Name:  "step",
+				Value: 1,
+				Usage: "The number of operations to undo",
+			},
+			&command.BoolFlag{
+				Name:    "list",
+				Aliases: []string{"l"},
+				Usage:   "List the recent operations instead of undoing them",
+			},
+			&command.IntFlag{
+				Name:  "limit",
+				Value: 10,
+				Usage: "The number of operations to list",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *UndoCommand) Handle(ctx console.Context) (err error) {
+	if ctx.OptionBool("list") {
+		operations, err := r.UndoService.GetRecentOperations(context.Background(), ctx.OptionInt("limit"))
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		if len(operations) == 0 {
+			ctx.Info("No operations recorded yet.")
+			return nil
+		}
+
+		ctx.NewLine()
+		color.Println("<fg=blue;op=bold>Recent Operations:</>")
+		ctx.NewLine()
+		for _, operation := range operations {
+			ctx.TwoColumnDetail(operationLabel(operation), operationState(operation))
+		}
+		ctx.NewLine()
+		return nil
+	}
+
+	operations, err := r.UndoService.Undo(context.Background(), ctx.OptionInt("step"))
+	for _, operation := range operations {
+		ctx.TwoColumnDetail(operationLabel(operation), color.Sprint("<fg=green;op=bold>UNDONE</>"))
+	}
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.Success("Undo completed successfully. Run \"todo redo\" to reapply.")
+	return nil
+}
+
+// operationLabel renders an operation as its ID, description and time.
+func operationLabel(operation models.Operation) string {
+	return color.Sprintf("<fg=white;op=bold>%d</> %s <fg=gray>(%s, %s)</>", operation.ID, operation.Description,
+		operation.CreatedAt.Local().Format(time.RFC822), pluralize(operation.EventCount, "change"))
+}
+
+// operationState renders whether an operation is in effect, undone or can no longer be redone.
+func operationState(operation models.Operation) string {
+	switch {
+	case operation.Redoable:
+		return color.Sprint("<fg=yellow>undone</>")
+	case operation.UndoneAt != nil:
+		return color.Sprint("<fg=gray>undone, discarded</>")
+	default:
+		return color.Sprint("<fg=green>applied</>")
+	}
+}
+
+// pluralize renders a count followed by a noun, adding an "s" unless the count is one.
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(count) + " " + noun + "s"
+}
@@ -3,19 +3,22 @@ package commands
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/goravel/framework/contracts/console"
 	"github.com/goravel/framework/contracts/console/command"
 
-	"github.com/kkumar-gcc/todo/constants"
 	"github.com/kkumar-gcc/todo/models"
 	"github.com/kkumar-gcc/todo/services"
 )
 
 type UpdateTaskCommand struct {
-	TaskService services.TaskService
+	TaskService    services.TaskService
+	ProjectService services.ProjectService
+	StatusService  services.StatusService
+	Priorities     models.PriorityScale
 }
 
 // Signature The name and signature of the console command.
@@ -38,6 +41,58 @@ func (r *UpdateTaskCommand) Extend() command.Extend {
 				Aliases: []string{"i"},
 				Usage:   "The ID of the task to update",
 			},
+			&command.StringFlag{
+				Name:    "priority",
+				Aliases: []string{"p"},
+				Usage:   "The new priority of the task, by name or rank",
+			},
+			&command.StringFlag{
+				Name:    "status",
+				Aliases: []string{"s"},
+				Usage:   "The new status of the task, by name or ID; the workflow decides which statuses it can move to",
+			},
+			&command.StringFlag{
+				Name:  "reason",
+				Usage: "Why the task has its new status, required for some statuses such as cancelled",
+			},
+			&command.StringFlag{
+				Name:    "due",
+				Aliases: []string{"d"},
+				Usage:   "The new due date of the task, or \"none\" to clear it",
+			},
+			&command.StringFlag{
+				Name:    "repeat",
+				Aliases: []string{"r"},
+				Usage:   "Repeat the task when completed (daily, weekly, monthly, every 3 days, mon,wed,fri or an RRULE), or \"none\" to stop the series",
+			},
+			&command.StringFlag{
+				Name:  "until",
+				Usage: "Stop repeating after this date",
+			},
+			&command.IntFlag{
+				Name:  "count",
+				Usage: "Stop repeating after this many occurrences",
+			},
+			&command.BoolFlag{
+				Name:  "force",
+				Usage: "Start or complete the task even if it has open subtasks or blockers",
+			},
+			&command.StringFlag{
+				Name:  "project",
+				Usage: "Move the task to this project (name or ID), or \"none\" to remove it from its project",
+			},
+			&command.StringFlag{
+				Name:  "estimate",
+				Usage: "The expected effort, as a duration (e.g. 30m, 2h) or in story points (e.g. 3pt), or \"none\" to clear it",
+			},
+			&command.StringFlag{
+				Name:  "notes",
+				Usage: "Replace the Markdown notes of the task",
+			},
+			&command.StringFlag{
+				Name:  "notes-file",
+				Usage: "Read the notes from a Markdown file, or \"-\" for standard input",
+			},
 		},
 	}
 }
@@ -93,63 +148,168 @@ func (r *UpdateTaskCommand) Handle(ctx console.Context) (err error) {
 		return nil
 	}
 
-	priorityChoices := []console.Choice{
-		{Key: constants.PriorityColors[constants.PriorityLow], Value: strconv.Itoa(constants.PriorityLow)},
-		{Key: constants.PriorityColors[constants.PriorityMedium], Value: strconv.Itoa(constants.PriorityMedium)},
-		{Key: constants.PriorityColors[constants.PriorityHigh], Value: strconv.Itoa(constants.PriorityHigh)},
+	priority := ctx.Option("priority")
+	if priority == "" {
+		priority, err = ctx.Choice("Select priority for the task:", priorityChoices(r.Priorities), console.ChoiceOption{
+			Default:     strconv.Itoa(task.Priority),
+			Description: "Choose a priority for the task",
+		})
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
 	}
-	priority, err := ctx.Choice("Select priority for the task:", priorityChoices, console.ChoiceOption{
-		Default:     strconv.Itoa(task.Priority),
-		Description: "Choose a priority for the task",
-	})
+
+	priorityRank, err := resolvePriority(r.Priorities, priority)
 	if err != nil {
 		ctx.Error(err.Error())
 		return nil
 	}
 
-	statusChoices := []console.Choice{
-		{Key: constants.StatusColors[constants.StatusPending], Value: strconv.Itoa(constants.StatusPending)},
-		{Key: constants.StatusColors[constants.StatusInProgress], Value: strconv.Itoa(constants.StatusInProgress)},
-		{Key: constants.StatusColors[constants.StatusCompleted], Value: strconv.Itoa(constants.StatusCompleted)},
+	status := ctx.Option("status")
+	if status == "" {
+		workflow, err := r.StatusService.GetWorkflow(context.Background())
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		statuses := workflow.Targets(task.Status)
+		if current := workflow.Status(task.Status); current != nil {
+			statuses = append([]models.Status{*current}, statuses...)
+		}
+		status, err = ctx.Choice("Select status for the task:", statusChoices(statuses), console.ChoiceOption{
+			Default:     strconv.Itoa(task.Status),
+			Description: "Choose a status for the task",
+		})
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
 	}
-	status, err := ctx.Choice("Select status for the task:", statusChoices, console.ChoiceOption{
-		Default:     strconv.Itoa(task.Status),
-		Description: "Choose a status for the task",
-	})
+
+	taskStatus, err := r.StatusService.GetStatus(context.Background(), status)
 	if err != nil {
 		ctx.Error(err.Error())
 		return nil
 	}
 
+	reason := ctx.Option("reason")
+	if taskStatus.ID != task.Status && taskStatus.RequiresReason && strings.TrimSpace(reason) == "" {
+		if reason, err = askReason(ctx, taskStatus); err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+
 	tags, err := ctx.Ask("Enter tags for the task (comma-separated):", console.AskOption{
 		Placeholder: "E.g., work,urgent",
 		Prompt:      "> ",
-		Default:     task.Tags,
+		Default:     strings.Join(task.Tags, ","),
 	})
 	if err != nil {
 		ctx.Error(err.Error())
 		return nil
 	}
 
-	priorityInt, err := strconv.Atoi(priority)
+	due := ctx.Option("due")
+	if due == "" {
+		due, err = ctx.Ask("Enter a due date for the task (\"none\" to clear):", console.AskOption{
+			Placeholder: "E.g., tomorrow, next fri, +3d, 2026-11-01 17:00",
+			Prompt:      "> ",
+			Default:     formatDue(task.DueAt),
+			Validate: func(value string) error {
+				_, err := parseDue(value)
+				return err
+			},
+		})
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+
+	dueAt, err := parseDue(due)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	repeat := ctx.Option("repeat")
+	if repeat == "" {
+		repeat = task.Recurrence
+	}
+	rrule, err := buildRecurrence(repeat, ctx.Option("until"), ctx.OptionInt("count"))
 	if err != nil {
 		ctx.Error(err.Error())
 		return nil
 	}
 
-	statusInt, err := strconv.Atoi(status)
+	notes, hasNotes, err := readNotes(ctx.Option("notes"), ctx.Option("notes-file"))
 	if err != nil {
 		ctx.Error(err.Error())
 		return nil
 	}
 
-	err = r.TaskService.UpdateTask(context.Background(), id, func(t *models.Task) (*models.Task, error) {
+	projectID := task.ProjectID
+	if name := ctx.Option("project"); strings.EqualFold(name, "none") {
+		projectID = nil
+	} else if name != "" {
+		project, err := r.ProjectService.GetProject(context.Background(), name)
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		projectID = &project.ID
+	}
+
+	estimate := task.Estimate
+	if value := ctx.Option("estimate"); value != "" {
+		if estimate, err = models.ParseEstimate(value); err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+
+	update := func(t *models.Task) (*models.Task, error) {
 		t.Title = title
-		t.Priority = priorityInt
-		t.Status = statusInt
-		t.Tags = tags
+		if hasNotes {
+			t.Notes = notes
+		}
+		t.Priority = priorityRank
+		t.Status = taskStatus.ID
+		if reason != "" {
+			t.StatusReason = reason
+		}
+		t.Tags = services.ParseTags(tags)
+		t.DueAt = dueAt
+		t.Recurrence = rrule
+		t.ProjectID = projectID
+		t.Estimate = estimate
 		return t, nil
-	})
+	}
+
+	var opts []services.UpdateOption
+	if ctx.OptionBool("force") {
+		opts = append(opts, services.Force())
+	}
+
+	err = r.TaskService.UpdateTask(context.Background(), id, update, opts...)
+	if errors.Is(err, services.ErrOpenSubtasks) || errors.Is(err, services.ErrTaskBlocked) {
+		force, confirmErr := ctx.Confirm(fmt.Sprintf("Warning: %s. Continue anyway?", err), console.ConfirmOption{
+			Affirmative: "Yes",
+			Negative:    "No",
+		})
+		if confirmErr != nil {
+			ctx.Error(confirmErr.Error())
+			return nil
+		}
+		if !force {
+			ctx.Info("Task update cancelled.")
+			return nil
+		}
+
+		err = r.TaskService.UpdateTask(context.Background(), id, update, services.Force())
+	}
 	if err != nil {
 		ctx.Error("Failed to update task: " + err.Error())
 		return nil
@@ -1,8 +1,11 @@
 package console
 
 import (
+	"log"
+
 	"github.com/goravel/framework/contracts/console"
 
+	"github.com/kkumar-gcc/todo/config"
 	"github.com/kkumar-gcc/todo/console/commands"
 	"github.com/kkumar-gcc/todo/database"
 	"github.com/kkumar-gcc/todo/repositories"
@@ -13,21 +16,196 @@ type Kernel struct {
 }
 
 func (kernel *Kernel) Commands() []console.Command {
-	taskRepository := repositories.NewTaskRepository(database.GetInstance())
-	taskService := services.NewTaskService(taskRepository)
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db := database.GetInstance()
+	migrator := database.NewMigrator(db)
+	taskRepository := repositories.NewTaskRepository(db)
+	operationRepository := repositories.NewOperationRepository(db)
+	statusRepository := repositories.NewStatusRepository(db)
+	statusService := services.NewStatusService(statusRepository)
+	taskService := services.NewTaskService(taskRepository, operationRepository, statusRepository, cfg.Priorities, cfg.Urgency)
+	undoService := services.NewUndoService(operationRepository)
+	timeEntryRepository := repositories.NewTimeEntryRepository(db)
+	pomodoroRepository := repositories.NewPomodoroRepository(db)
+	timeService := services.NewTimeService(timeEntryRepository, pomodoroRepository, taskService)
+	reportService := services.NewReportService(timeEntryRepository, taskRepository)
+	projectRepository := repositories.NewProjectRepository(db)
+	projectService := services.NewProjectService(projectRepository)
+	taskEventRepository := repositories.NewTaskEventRepository(db)
+	historyService := services.NewHistoryService(taskEventRepository)
+	tagRepository := repositories.NewTagRepository(db)
+	tagService := services.NewTagService(tagRepository, operationRepository)
+	templatesDir, err := config.TemplatesDir()
+	if err != nil {
+		log.Fatal(err)
+	}
+	templateService := services.NewTemplateService(repositories.NewTemplateRepository(templatesDir), taskService, projectService, cfg.Priorities)
 	return []console.Command{
 		&commands.AddTaskCommand{
-			TaskService: taskService,
+			TaskService:    taskService,
+			ProjectService: projectService,
+			StatusService:  statusService,
+			Priorities:     cfg.Priorities,
 		},
 		&commands.ListTasksCommand{
+			TaskService:    taskService,
+			ProjectService: projectService,
+			StatusService:  statusService,
+			Config:         cfg,
+		},
+		&commands.SearchTasksCommand{
+			TaskService:   taskService,
+			StatusService: statusService,
+			Priorities:    cfg.Priorities,
+		},
+		&commands.NextTasksCommand{
+			TaskService: taskService,
+			Priorities:  cfg.Priorities,
+		},
+		&commands.ShowTaskCommand{
+			TaskService: taskService,
+			TimeService: timeService,
+			Priorities:  cfg.Priorities,
+		},
+		&commands.EditTaskCommand{
+			TaskService:   taskService,
+			StatusService: statusService,
+			Priorities:    cfg.Priorities,
+		},
+		&commands.TaskHistoryCommand{
+			HistoryService: historyService,
+			StatusService:  statusService,
+			Priorities:     cfg.Priorities,
+		},
+		&commands.ArchiveTaskCommand{
+			TaskService: taskService,
+			Config:      cfg,
+		},
+		&commands.UnarchiveTaskCommand{
+			TaskService: taskService,
+		},
+		&commands.SnoozeTaskCommand{
 			TaskService: taskService,
 		},
 		&commands.DeleteTaskCommand{
 			TaskService: taskService,
+			Priorities:  cfg.Priorities,
 		},
 		&commands.UpdateTaskCommand{
+			TaskService:    taskService,
+			ProjectService: projectService,
+			StatusService:  statusService,
+			Priorities:     cfg.Priorities,
+		},
+		&commands.DependTaskCommand{
+			TaskService: taskService,
+		},
+		&commands.UndependTaskCommand{
+			TaskService: taskService,
+		},
+		&commands.StartTaskCommand{
+			TimeService: timeService,
+		},
+		&commands.StopTaskCommand{
+			TimeService: timeService,
+		},
+		&commands.LogTimeCommand{
+			TimeService: timeService,
+		},
+		&commands.FocusTaskCommand{
+			TaskService: taskService,
+			TimeService: timeService,
+		},
+		&commands.ReportTimeCommand{
+			ReportService: reportService,
+		},
+		&commands.ReportEstimatesCommand{
+			ReportService: reportService,
+		},
+		&commands.AddProjectCommand{
+			ProjectService: projectService,
+		},
+		&commands.ListProjectsCommand{
+			ProjectService: projectService,
+		},
+		&commands.ShowProjectCommand{
+			ProjectService: projectService,
+			TaskService:    taskService,
+			Priorities:     cfg.Priorities,
+		},
+		&commands.ArchiveProjectCommand{
+			ProjectService: projectService,
+		},
+		&commands.RenameProjectCommand{
+			ProjectService: projectService,
+		},
+		&commands.ListStatusesCommand{
+			StatusService: statusService,
+		},
+		&commands.AddStatusCommand{
+			StatusService: statusService,
+		},
+		&commands.AllowStatusCommand{
+			StatusService: statusService,
+		},
+		&commands.DisallowStatusCommand{
+			StatusService: statusService,
+		},
+		&commands.ListPrioritiesCommand{
+			Priorities: cfg.Priorities,
+		},
+		&commands.MigratePrioritiesCommand{
+			TaskService: taskService,
+			Priorities:  cfg.Priorities,
+		},
+		&commands.ListTemplatesCommand{
+			TemplateService: templateService,
+			Dir:             templatesDir,
+		},
+		&commands.ApplyTemplateCommand{
+			TemplateService: templateService,
+			Priorities:      cfg.Priorities,
+		},
+		&commands.ListTagsCommand{
+			TagService: tagService,
+		},
+		&commands.RenameTagCommand{
+			TagService: tagService,
+		},
+		&commands.MergeTagsCommand{
+			TagService: tagService,
+		},
+		&commands.DeleteTagCommand{
+			TagService: tagService,
+		},
+		&commands.ListTrashCommand{
+			TaskService: taskService,
+		},
+		&commands.RestoreTaskCommand{
 			TaskService: taskService,
 		},
+		&commands.EmptyTrashCommand{
+			TaskService: taskService,
+		},
+		&commands.UndoCommand{
+			UndoService: undoService,
+		},
+		&commands.RedoCommand{
+			UndoService: undoService,
+		},
 		&commands.InitCommand{},
+		&commands.MigrateCommand{
+			Migrator: migrator,
+		},
+		&commands.MigrateStatusCommand{
+			Migrator: migrator,
+		},
+		&commands.MigrateRollbackCommand{
+			Migrator: migrator,
+		},
 	}
 }
@@ -0,0 +1,4 @@
+package constants
+
+// Colors - The color names that can be assigned to projects and other labels
+var Colors = []string{"red", "green", "yellow", "blue", "magenta", "cyan", "white", "gray"}
@@ -0,0 +1,6 @@
+package constants
+
+var (
+	ConfigFileName   = "config.yaml"
+	TemplatesDirName = "templates"
+)
@@ -1,66 +1,105 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	"embed"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/goravel/framework/support/color"
+	"github.com/mattn/go-sqlite3"
 
+	"github.com/kkumar-gcc/todo/config"
 	"github.com/kkumar-gcc/todo/constants"
+	"github.com/kkumar-gcc/todo/models"
 )
 
-//go:embed migrations/todo_schema.sql
-var todoSchema embed.FS
+// driverName is the SQLite driver with the functions todo adds to SQL, such as normalize_tag(name),
+// which migrations use to clean up data the same way the application does.
+const driverName = "sqlite3_todo"
+
+func init() {
+	sql.Register(driverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("normalize_tag", models.NormalizeTag, true)
+		},
+	})
+}
 
 // GetInstance returns a singleton instance of the database connection.
+// It refuses to continue if the database was migrated by a newer version of todo, and warns when an
+// older database has migrations pending.
 func GetInstance() *sql.DB {
 	dbPath, err := GetDatabasePath()
 	if err != nil {
 		log.Fatal("Failed to get database path:", err)
 	}
 
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := Open(dbPath)
 	if err != nil {
 		log.Fatal("Failed to connect to the database:", err)
 	}
 
+	migrator := NewMigrator(db)
+	statuses, err := migrator.Check(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !migrating() {
+		warnPending(statuses)
+	}
+
 	return db
 }
 
-// RunMigration reads the schema from the todo_schema.sql file and applies it.
-func RunMigration() error {
-	db := GetInstance()
-	defer db.Close()
+// Open opens the SQLite database at the given path with foreign keys enforced and the functions todo adds to SQL.
+func Open(path string) (*sql.DB, error) {
+	return sql.Open(driverName, path+"?_foreign_keys=on")
+}
 
-	schema, err := todoSchema.ReadFile("migrations/todo_schema.sql")
-	if err != nil {
-		return fmt.Errorf("failed to read todo_schema.sql file: %v", err)
+// warnPending tells the user to run todo migrate when the database was set up by an older version of todo
+// and is missing migrations, as commands would otherwise fail on tables or columns that do not exist yet.
+// A database without any migration has not been set up, which todo init takes care of.
+func warnPending(statuses []MigrationStatus) {
+	var applied, pending int
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		} else {
+			applied++
+		}
+	}
+	if applied > 0 && pending > 0 {
+		color.Warningln(fmt.Sprintf("The database has %d pending migrations, run `todo migrate` to apply them.", pending))
 	}
+}
 
-	_, err = db.Exec(string(schema))
-	if err != nil {
-		return fmt.Errorf("failed to apply schema: %v", err)
+// migrating reports whether todo was run to set up or migrate the database, which needs no warning about pending migrations.
+func migrating() bool {
+	if len(os.Args) < 2 {
+		return false
 	}
+	return os.Args[1] == "init" || strings.HasPrefix(os.Args[1], "migrate")
+}
 
-	return nil
+// RunMigration applies every pending migration to the database.
+func RunMigration() error {
+	db := GetInstance()
+	defer db.Close()
+
+	_, err := NewMigrator(db).Migrate(context.Background())
+	return err
 }
 
 // GetDatabasePath returns the path to the SQLite database, storing it in a standard location.
 func GetDatabasePath() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	dbDir, err := config.Dir()
 	if err != nil {
 		return "", err
 	}
 
-	dbDir := filepath.Join(homeDir, ".config", "todo")
-
-	if err := os.MkdirAll(dbDir, os.ModePerm); err != nil {
-		return "", err
-	}
-
 	return filepath.Join(dbDir, constants.SqliteDatabaseName), nil
 }
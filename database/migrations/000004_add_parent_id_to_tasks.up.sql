@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN parent_id INTEGER REFERENCES tasks (id);
+
+CREATE INDEX idx_tasks_parent_id ON tasks (parent_id);
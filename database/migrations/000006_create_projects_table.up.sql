@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS projects (
+     id INTEGER PRIMARY KEY AUTOINCREMENT,
+     name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+     description TEXT NOT NULL DEFAULT '',
+     color TEXT NOT NULL DEFAULT '',
+     archived BOOLEAN NOT NULL DEFAULT 0,
+     created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE tasks ADD COLUMN project_id INTEGER REFERENCES projects (id);
+
+CREATE INDEX idx_tasks_project_id ON tasks (project_id);
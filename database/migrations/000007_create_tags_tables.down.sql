@@ -0,0 +1,9 @@
+ALTER TABLE tasks ADD COLUMN tags TEXT;
+
+UPDATE tasks SET tags = COALESCE((
+    SELECT GROUP_CONCAT(tags.name, ',') FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE task_tags.task_id = tasks.id
+), '');
+
+DROP TABLE IF EXISTS task_tags;
+
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS tags (
+     id INTEGER PRIMARY KEY AUTOINCREMENT,
+     name TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS task_tags (
+     task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+     tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+     PRIMARY KEY (task_id, tag_id)
+);
+
+CREATE INDEX idx_task_tags_tag_id ON task_tags (tag_id);
+
+CREATE TEMP TABLE split_task_tags AS
+WITH RECURSIVE split (task_id, name, rest) AS (
+    SELECT id, '', tags || ',' FROM tasks WHERE tags IS NOT NULL AND tags != ''
+    UNION ALL
+    SELECT task_id, substr(rest, 1, instr(rest, ',') - 1), substr(rest, instr(rest, ',') + 1)
+    FROM split WHERE rest != ''
+)
+SELECT DISTINCT task_id, replace(lower(trim(name)), ' ', '-') AS name FROM split WHERE trim(name) != '';
+
+INSERT OR IGNORE INTO tags (name) SELECT name FROM split_task_tags ORDER BY name;
+
+INSERT OR IGNORE INTO task_tags (task_id, tag_id)
+SELECT split_task_tags.task_id, tags.id FROM split_task_tags JOIN tags ON tags.name = split_task_tags.name;
+
+DROP TABLE split_task_tags;
+
+ALTER TABLE tasks DROP COLUMN tags;
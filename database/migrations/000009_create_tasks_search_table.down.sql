@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS tasks_search_after_tag_rename;
+
+DROP TRIGGER IF EXISTS tasks_search_after_tag_delete;
+
+DROP TRIGGER IF EXISTS tasks_search_after_tag_insert;
+
+DROP TRIGGER IF EXISTS tasks_search_after_delete;
+
+DROP TRIGGER IF EXISTS tasks_search_after_update;
+
+DROP TRIGGER IF EXISTS tasks_search_after_insert;
+
+DROP TABLE IF EXISTS tasks_search;
@@ -0,0 +1,45 @@
+-- feature: fts5
+-- Full-text search is optional: without FTS5 support this migration is skipped and task:search is unavailable.
+
+CREATE VIRTUAL TABLE tasks_search USING fts5 (
+     title,
+     tags,
+     notes,
+     tokenize = 'unicode61 remove_diacritics 2',
+     prefix = '2 3'
+);
+
+INSERT INTO tasks_search (rowid, title, tags, notes)
+SELECT id, title, COALESCE((
+    SELECT GROUP_CONCAT(tags.name, ' ') FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE task_tags.task_id = tasks.id
+), ''), notes FROM tasks;
+
+CREATE TRIGGER tasks_search_after_insert AFTER INSERT ON tasks BEGIN
+    INSERT INTO tasks_search (rowid, title, tags, notes) VALUES (new.id, new.title, '', new.notes);
+END;
+
+CREATE TRIGGER tasks_search_after_update AFTER UPDATE OF title, notes ON tasks BEGIN
+    UPDATE tasks_search SET title = new.title, notes = new.notes WHERE rowid = new.id;
+END;
+
+CREATE TRIGGER tasks_search_after_delete AFTER DELETE ON tasks BEGIN
+    DELETE FROM tasks_search WHERE rowid = old.id;
+END;
+
+CREATE TRIGGER tasks_search_after_tag_insert AFTER INSERT ON task_tags BEGIN
+    UPDATE tasks_search SET tags = (
+        SELECT GROUP_CONCAT(tags.name, ' ') FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE task_tags.task_id = new.task_id
+    ) WHERE rowid = new.task_id;
+END;
+
+CREATE TRIGGER tasks_search_after_tag_delete AFTER DELETE ON task_tags BEGIN
+    UPDATE tasks_search SET tags = COALESCE((
+        SELECT GROUP_CONCAT(tags.name, ' ') FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE task_tags.task_id = old.task_id
+    ), '') WHERE rowid = old.task_id;
+END;
+
+CREATE TRIGGER tasks_search_after_tag_rename AFTER UPDATE OF name ON tags BEGIN
+    UPDATE tasks_search SET tags = (
+        SELECT GROUP_CONCAT(tags.name, ' ') FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE task_tags.task_id = tasks_search.rowid
+    ) WHERE rowid IN (SELECT task_id FROM task_tags WHERE tag_id = new.id);
+END;
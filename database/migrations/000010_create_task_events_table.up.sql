@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS task_events (
+     id INTEGER PRIMARY KEY AUTOINCREMENT,
+     task_id INTEGER NOT NULL,
+     type TEXT NOT NULL,
+     title TEXT NOT NULL DEFAULT '',
+     changes TEXT NOT NULL DEFAULT '[]',
+     actor TEXT NOT NULL DEFAULT '',
+     created_at DATETIME NOT NULL
+);
+
+CREATE INDEX idx_task_events_task_id ON task_events (task_id);
+
+CREATE INDEX idx_task_events_created_at ON task_events (created_at);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_task_events_operation_id;
+
+ALTER TABLE task_events DROP COLUMN snapshot_after;
+
+ALTER TABLE task_events DROP COLUMN snapshot_before;
+
+ALTER TABLE task_events DROP COLUMN operation_id;
+
+DROP TABLE IF EXISTS operations;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS operations (
+     id INTEGER PRIMARY KEY AUTOINCREMENT,
+     description TEXT NOT NULL,
+     created_at DATETIME NOT NULL,
+     undone_at DATETIME,
+     redoable BOOLEAN NOT NULL DEFAULT 0
+);
+
+ALTER TABLE task_events ADD COLUMN operation_id INTEGER;
+
+ALTER TABLE task_events ADD COLUMN snapshot_before TEXT;
+
+ALTER TABLE task_events ADD COLUMN snapshot_after TEXT;
+
+CREATE INDEX idx_task_events_operation_id ON task_events (operation_id);
@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX idx_tasks_deleted_at ON tasks (deleted_at);
@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN archived_at DATETIME;
+
+CREATE INDEX idx_tasks_archived_at ON tasks (archived_at);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS time_entries (
+     id INTEGER PRIMARY KEY AUTOINCREMENT,
+     task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+     started_at DATETIME NOT NULL,
+     ended_at DATETIME,
+     note TEXT NOT NULL DEFAULT '',
+     CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE INDEX idx_time_entries_task_id ON time_entries (task_id);
+
+CREATE INDEX idx_time_entries_started_at ON time_entries (started_at);
+
+-- At most one timer can be running at a time.
+CREATE UNIQUE INDEX idx_time_entries_running ON time_entries ((ended_at IS NULL)) WHERE ended_at IS NULL;
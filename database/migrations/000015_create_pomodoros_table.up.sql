@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS pomodoros (
+     id INTEGER PRIMARY KEY AUTOINCREMENT,
+     task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+     time_entry_id INTEGER REFERENCES time_entries (id) ON DELETE SET NULL,
+     started_at DATETIME NOT NULL,
+     ended_at DATETIME NOT NULL,
+     planned_seconds INTEGER NOT NULL,
+     completed BOOLEAN NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_pomodoros_task_id ON pomodoros (task_id);
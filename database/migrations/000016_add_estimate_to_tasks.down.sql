@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN estimate_points;
+ALTER TABLE tasks DROP COLUMN estimate_seconds;
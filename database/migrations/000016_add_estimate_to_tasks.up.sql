@@ -0,0 +1,2 @@
+ALTER TABLE tasks ADD COLUMN estimate_seconds INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN estimate_points INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE tasks DROP COLUMN status_reason;
+
+DROP TABLE IF EXISTS status_transitions;
+DROP TABLE IF EXISTS statuses;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS statuses (
+     id INTEGER PRIMARY KEY AUTOINCREMENT,
+     name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+     label TEXT NOT NULL,
+     color TEXT NOT NULL DEFAULT '',
+     category TEXT NOT NULL CHECK (category IN ('open', 'active', 'done')),
+     requires_reason BOOLEAN NOT NULL DEFAULT 0,
+     position INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS status_transitions (
+     from_status_id INTEGER NOT NULL REFERENCES statuses (id) ON DELETE CASCADE,
+     to_status_id INTEGER NOT NULL REFERENCES statuses (id) ON DELETE CASCADE,
+     PRIMARY KEY (from_status_id, to_status_id)
+);
+
+-- The first three keep the IDs tasks have always been stored with.
+INSERT INTO statuses (id, name, label, color, category, requires_reason, position) VALUES
+     (1, 'pending', 'Pending', 'blue', 'open', 0, 1),
+     (2, 'in-progress', 'In Progress', 'cyan', 'active', 0, 3),
+     (3, 'completed', 'Completed', 'magenta', 'done', 0, 5),
+     (4, 'blocked', 'Blocked', 'red', 'open', 0, 2),
+     (5, 'in-review', 'In Review', 'yellow', 'active', 0, 4),
+     (6, 'cancelled', 'Cancelled', 'gray', 'done', 1, 6);
+
+INSERT INTO status_transitions (from_status_id, to_status_id) VALUES
+     (1, 2), (1, 3), (1, 4), (1, 6),
+     (2, 1), (2, 3), (2, 4), (2, 5), (2, 6),
+     (3, 1), (3, 2),
+     (4, 1), (4, 2), (4, 6),
+     (5, 2), (5, 3), (5, 6),
+     (6, 1);
+
+ALTER TABLE tasks ADD COLUMN status_reason TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- irreversible
+-- The original out-of-range priorities are not kept, so they cannot be restored.
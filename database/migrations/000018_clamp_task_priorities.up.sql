@@ -0,0 +1,4 @@
+-- Priorities were only checked for being non-negative, so move values outside the built-in
+-- low, medium and high scale to its nearest level.
+UPDATE tasks SET priority = 1 WHERE priority < 1;
+UPDATE tasks SET priority = 3 WHERE priority > 3;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tasks_wait_until;
+
+ALTER TABLE tasks DROP COLUMN resurfaced_at;
+ALTER TABLE tasks DROP COLUMN wait_until;
@@ -0,0 +1,4 @@
+ALTER TABLE tasks ADD COLUMN wait_until DATETIME;
+ALTER TABLE tasks ADD COLUMN resurfaced_at DATETIME;
+
+CREATE INDEX idx_tasks_wait_until ON tasks (wait_until);
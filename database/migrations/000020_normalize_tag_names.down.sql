@@ -0,0 +1,2 @@
+-- Normalized names are valid tags for the earlier schema too, and the spellings that were merged are
+-- not kept, so there is nothing to revert.
@@ -0,0 +1,15 @@
+-- Tags backfilled by 000007 had every space replaced by a dash, so "big  work" became "big--work", and
+-- tags could be given names containing commas, which split apart when tag lists are read back. Rename every tag to its normalized name,
+-- merging tags that end up with the same one.
+INSERT OR IGNORE INTO tags (name)
+SELECT normalize_tag(name) FROM tags WHERE normalize_tag(name) != name AND normalize_tag(name) != '';
+
+INSERT OR IGNORE INTO task_tags (task_id, tag_id)
+SELECT task_tags.task_id, normalized.id FROM task_tags
+JOIN tags ON tags.id = task_tags.tag_id
+JOIN tags AS normalized ON normalized.name = normalize_tag(tags.name)
+WHERE normalized.id != tags.id;
+
+DELETE FROM task_tags WHERE tag_id IN (SELECT id FROM tags WHERE normalize_tag(name) != name);
+
+DELETE FROM tags WHERE normalize_tag(name) != name;
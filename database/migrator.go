@@ -0,0 +1,373 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// migrationFeature matches the "-- feature: fts5" line of a migration that adds an optional SQLite feature.
+var migrationFeature = regexp.MustCompile(`(?m)^--\s*feature:\s*(\w+)\s*$`)
+
+// migrationIrreversible matches the "-- irreversible" line of a down migration that cannot undo its changes.
+var migrationIrreversible = regexp.MustCompile(`(?m)^--\s*irreversible\s*$`)
+
+// features maps the optional SQLite features migrations can use to the compile option that enables them.
+var features = map[string]string{
+	"fts5": "ENABLE_FTS5",
+}
+
+var (
+	ErrDatabaseTooNew = errors.New("database schema is newer than this version of todo, please upgrade")
+	ErrIrreversible   = errors.New("migration cannot be rolled back, as it does not keep the data it changed")
+	ErrFeatureMissing = errors.New("the database uses an SQLite feature this build of todo lacks, reinstall it with: go install -tags sqlite_fts5 github.com/kkumar-gcc/todo@latest")
+)
+
+// Migration is a single versioned schema change with its up and down SQL.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+	Feature string // Optional SQLite feature the migration needs, e.g. "fts5"; it is skipped when the build lacks it
+
+	Irreversible bool // Set by an "-- irreversible" line in the down migration, which then refuses to run
+}
+
+// MigrationStatus describes a known migration and when it was applied, if ever.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+	Skipped   bool // Recorded as applied without running, as this build lacks the migration's feature
+}
+
+// Migrator applies and rolls back the embedded migrations, tracking them in the schema_migrations table.
+type Migrator struct {
+	db *sql.DB
+}
+
+// NewMigrator creates a new Migrator for the given database connection.
+func NewMigrator(db *sql.DB) *Migrator {
+	return &Migrator{
+		db: db,
+	}
+}
+
+// Migrations returns every embedded migration ordered by version.
+func Migrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %v", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		matches := migrationFileName.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(matches[1])
+		contents, err := migrationFiles.ReadFile("migrations/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %v", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != matches[2] {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, migration.Name, matches[2])
+		}
+
+		if matches[3] == "up" {
+			migration.Up = string(contents)
+			if feature := migrationFeature.FindStringSubmatch(migration.Up); feature != nil {
+				if _, ok := features[feature[1]]; !ok {
+					return nil, fmt.Errorf("migration %s needs unknown feature %q", entry.Name(), feature[1])
+				}
+				migration.Feature = feature[1]
+			}
+		} else {
+			migration.Down = string(contents)
+			migration.Irreversible = migrationIrreversible.MatchString(migration.Down)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Check returns the status of every known migration, so that callers need not read it again. It fails
+// with ErrDatabaseTooNew if the database has migrations this binary does not know about, and with
+// ErrFeatureMissing if it has migrations needing a feature this binary lacks, such as the full-text search
+// triggers that every change to a task goes through.
+func (r *Migrator) Check(ctx context.Context) ([]MigrationStatus, error) {
+	statuses, applied, err := r.statuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest int
+	if len(statuses) > 0 {
+		latest = statuses[len(statuses)-1].Version
+	}
+	for version := range applied {
+		if version > latest {
+			return nil, fmt.Errorf("%w (database version %d, latest known version %d)", ErrDatabaseTooNew, version, latest)
+		}
+	}
+
+	for _, status := range statuses {
+		if status.AppliedAt == nil || status.Skipped {
+			continue
+		}
+		available, err := r.hasFeature(ctx, status.Feature)
+		if err != nil {
+			return nil, err
+		}
+		if !available {
+			return nil, fmt.Errorf("%w (migration %d_%s needs %s)", ErrFeatureMissing, status.Version, status.Name, status.Feature)
+		}
+	}
+
+	return statuses, nil
+}
+
+// Status returns every known migration together with the time it was applied.
+func (r *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	statuses, _, err := r.statuses(ctx)
+	return statuses, err
+}
+
+// statuses returns every known migration together with the time it was applied, and the versions
+// applied to the database, which may include some this binary does not know about.
+func (r *Migrator) statuses(ctx context.Context) ([]MigrationStatus, map[int]time.Time, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	skipped, err := r.skipped(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(migrations))
+	for i, migration := range migrations {
+		statuses[i] = MigrationStatus{Migration: migration, Skipped: skipped[migration.Version]}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+
+	return statuses, applied, nil
+}
+
+// Migrate applies all pending migrations in order and returns the ones that ran. A migration needing a
+// feature this build lacks is recorded as applied but skipped, so that the rest of the schema is still
+// upgraded; it runs on a later Migrate once a build with the feature is used.
+func (r *Migrator) Migrate(ctx context.Context) ([]MigrationStatus, error) {
+	statuses, err := r.Check(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []MigrationStatus
+	for _, status := range statuses {
+		if status.AppliedAt != nil && !status.Skipped {
+			continue
+		}
+
+		available, err := r.hasFeature(ctx, status.Feature)
+		if err != nil {
+			return ran, err
+		}
+		if status.Skipped && !available {
+			continue
+		}
+
+		err = r.transaction(ctx, func(tx *sql.Tx) error {
+			if status.Skipped {
+				// The migration was recorded when it was skipped; run it now that the feature is available.
+				if _, err := tx.ExecContext(ctx, status.Up); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx, "DELETE FROM skipped_migrations WHERE version = ?", status.Version)
+				return err
+			}
+			if available {
+				if _, err := tx.ExecContext(ctx, status.Up); err != nil {
+					return err
+				}
+			} else if _, err := tx.ExecContext(ctx, "INSERT INTO skipped_migrations (version) VALUES (?)", status.Version); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+				status.Version, status.Name, time.Now())
+			return err
+		})
+		if err != nil {
+			return ran, fmt.Errorf("failed to apply migration %d_%s: %w", status.Version, status.Name, err)
+		}
+
+		status.Skipped = !available
+		ran = append(ran, status)
+	}
+
+	return ran, nil
+}
+
+// Rollback reverts the given number of most recently applied migrations and returns the ones that were reverted.
+func (r *Migrator) Rollback(ctx context.Context, steps int) ([]Migration, error) {
+	statuses, err := r.Check(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var reverted []Migration
+	for i := len(statuses) - 1; i >= 0 && len(reverted) < steps; i-- {
+		status := statuses[i]
+		if status.AppliedAt == nil {
+			continue
+		}
+		if status.Irreversible {
+			return reverted, fmt.Errorf("%w: %d_%s", ErrIrreversible, status.Version, status.Name)
+		}
+
+		err := r.transaction(ctx, func(tx *sql.Tx) error {
+			// A skipped migration made no changes, so there is nothing to revert.
+			if !status.Skipped {
+				if _, err := tx.ExecContext(ctx, status.Down); err != nil {
+					return err
+				}
+			}
+			if _, err := tx.ExecContext(ctx, "DELETE FROM skipped_migrations WHERE version = ?", status.Version); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", status.Version)
+			return err
+		})
+		if err != nil {
+			return reverted, fmt.Errorf("failed to roll back migration %d_%s: %v", status.Version, status.Name, err)
+		}
+
+		reverted = append(reverted, status.Migration)
+	}
+
+	return reverted, nil
+}
+
+func (r *Migrator) applied(ctx context.Context) (map[int]time.Time, error) {
+	query := `CREATE TABLE IF NOT EXISTS schema_migrations (
+                  version INTEGER PRIMARY KEY,
+                  name TEXT NOT NULL,
+                  applied_at DATETIME NOT NULL
+              )`
+	if _, err := r.db.ExecContext(ctx, query); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations table: %v", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// skipped returns the versions of the migrations that were recorded as applied without running.
+func (r *Migrator) skipped(ctx context.Context) (map[int]bool, error) {
+	query := `CREATE TABLE IF NOT EXISTS skipped_migrations (
+                  version INTEGER PRIMARY KEY
+              )`
+	if _, err := r.db.ExecContext(ctx, query); err != nil {
+		return nil, fmt.Errorf("failed to create skipped_migrations table: %v", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT version FROM skipped_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skipped := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		skipped[version] = true
+	}
+
+	return skipped, rows.Err()
+}
+
+// hasFeature reports whether the SQLite library this binary was built with has an optional feature.
+// A migration without a feature always has what it needs.
+func (r *Migrator) hasFeature(ctx context.Context, feature string) (bool, error) {
+	if feature == "" {
+		return true, nil
+	}
+
+	var count int
+	query := "SELECT COUNT(*) FROM pragma_compile_options WHERE compile_options = ?"
+	if err := r.db.QueryRowContext(ctx, query, features[feature]).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *Migrator) transaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
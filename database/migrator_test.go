@@ -0,0 +1,209 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// openTestDB opens an empty database in a temporary directory.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := Open(filepath.Join(t.TempDir(), "todo.db"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// tables returns the names of the tables in the database, other than SQLite's own.
+func tables(t *testing.T, db *sql.DB) []string {
+	t.Helper()
+
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+	if err != nil {
+		t.Fatalf("failed to list tables: %v", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("failed to list tables: %v", err)
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("Migrations returned error: %v", err)
+	}
+
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("migration %d_%s has version %d, want %d", migration.Version, migration.Name, migration.Version, i+1)
+		}
+	}
+}
+
+func TestMigrationsUpAndDown(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	migrator := NewMigrator(db)
+
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("Migrations returned error: %v", err)
+	}
+
+	var applied []Migration
+	for _, migration := range migrations {
+		available, err := migrator.hasFeature(ctx, migration.Feature)
+		if err != nil {
+			t.Fatalf("hasFeature returned error: %v", err)
+		}
+		if !available {
+			continue
+		}
+		if _, err := db.Exec(migration.Up); err != nil {
+			t.Fatalf("migration %d_%s failed to apply: %v", migration.Version, migration.Name, err)
+		}
+		applied = append(applied, migration)
+	}
+
+	for i := len(applied) - 1; i >= 0; i-- {
+		if _, err := db.Exec(applied[i].Down); err != nil {
+			t.Fatalf("migration %d_%s failed to roll back: %v", applied[i].Version, applied[i].Name, err)
+		}
+	}
+
+	if names := tables(t, db); len(names) > 0 {
+		t.Errorf("tables left after rolling every migration back: %v", names)
+	}
+}
+
+func TestMigrateAndRollback(t *testing.T) {
+	ctx := context.Background()
+	migrator := NewMigrator(openTestDB(t))
+
+	ran, err := migrator.Migrate(ctx)
+	if err != nil {
+		t.Fatalf("Migrate returned error: %v", err)
+	}
+	migrations, _ := Migrations()
+	if len(ran) != len(migrations) {
+		t.Fatalf("Migrate ran %d migrations, want %d", len(ran), len(migrations))
+	}
+
+	if ran, err = migrator.Migrate(ctx); err != nil || len(ran) != 0 {
+		t.Fatalf("second Migrate ran %d migrations with error %v, want none", len(ran), err)
+	}
+
+	// Everything after the first irreversible migration can be rolled back.
+	var reversible int
+	for i := len(migrations) - 1; i >= 0 && !migrations[i].Irreversible; i-- {
+		reversible++
+	}
+	reverted, err := migrator.Rollback(ctx, reversible)
+	if err != nil {
+		t.Fatalf("Rollback(%d) returned error: %v", reversible, err)
+	}
+	if len(reverted) != reversible || reverted[0].Version != len(migrations) {
+		t.Fatalf("Rollback(%d) reverted %v, want the latest %d migrations", reversible, reverted, reversible)
+	}
+
+	if _, err := migrator.Rollback(ctx, 1); !errors.Is(err, ErrIrreversible) {
+		t.Fatalf("Rollback past an irreversible migration returned error %v, want %v", err, ErrIrreversible)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status returned error: %v", err)
+	}
+	for _, status := range statuses {
+		if pending := status.AppliedAt == nil; pending != (status.Version > len(migrations)-reversible) {
+			t.Errorf("migration %d_%s pending = %v after the rollback", status.Version, status.Name, pending)
+		}
+	}
+
+	if ran, err = migrator.Migrate(ctx); err != nil || len(ran) != reversible {
+		t.Fatalf("Migrate after the rollback ran %d migrations with error %v, want %d", len(ran), err, reversible)
+	}
+}
+
+func TestCheckDatabaseTooNew(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	migrator := NewMigrator(db)
+
+	if _, err := migrator.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate returned error: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (9999, 'from_the_future', CURRENT_TIMESTAMP)"); err != nil {
+		t.Fatalf("failed to record a newer migration: %v", err)
+	}
+
+	if _, err := migrator.Check(ctx); !errors.Is(err, ErrDatabaseTooNew) {
+		t.Errorf("Check returned error %v, want %v", err, ErrDatabaseTooNew)
+	}
+	if _, err := migrator.Migrate(ctx); !errors.Is(err, ErrDatabaseTooNew) {
+		t.Errorf("Migrate returned error %v, want %v", err, ErrDatabaseTooNew)
+	}
+}
+
+func TestNormalizeTagNamesMigration(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	migrator := NewMigrator(db)
+
+	if _, err := migrator.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate returned error: %v", err)
+	}
+	// Go back to the schema before 000020_normalize_tag_names.
+	migrations, _ := Migrations()
+	if _, err := migrator.Rollback(ctx, len(migrations)-19); err != nil {
+		t.Fatalf("Rollback returned error: %v", err)
+	}
+
+	// Tags as 000007 left them, before names were normalized.
+	setup := `INSERT INTO tasks (id, title) VALUES (1, 'first'), (2, 'second');
+INSERT INTO tags (id, name) VALUES (1, 'big--work'), (2, 'big-work'), (3, 'home,garden'), (4, ',');
+INSERT INTO task_tags (task_id, tag_id) VALUES (1, 1), (2, 2), (2, 3), (1, 4);`
+	if _, err := db.Exec(setup); err != nil {
+		t.Fatalf("failed to insert tags: %v", err)
+	}
+
+	if _, err := migrator.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate returned error: %v", err)
+	}
+
+	rows, err := db.Query("SELECT task_tags.task_id, tags.name FROM task_tags JOIN tags ON tags.id = task_tags.tag_id ORDER BY task_tags.task_id, tags.name")
+	if err != nil {
+		t.Fatalf("failed to read tags: %v", err)
+	}
+	defer rows.Close()
+
+	var got []string
+	for rows.Next() {
+		var taskID int
+		var name string
+		if err := rows.Scan(&taskID, &name); err != nil {
+			t.Fatalf("failed to read tags: %v", err)
+		}
+		got = append(got, fmt.Sprintf("%d:%s", taskID, name))
+	}
+
+	if want := []string{"1:big-work", "2:big-work", "2:home-garden"}; !slices.Equal(got, want) {
+		t.Errorf("task tags = %q, want %q", got, want)
+	}
+}
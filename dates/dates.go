@@ -0,0 +1,230 @@
+package dates
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidDate     = errors.New("unrecognized date, try e.g. tomorrow, next fri, +3d or 2026-11-01 17:00")
+	ErrInvalidDuration = errors.New("unrecognized duration, try e.g. 30m, 1h30m, 3d or 2w")
+)
+
+// Layout is the format used when showing a date that the user may want to edit and parse back.
+const Layout = "2006-01-02 15:04"
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var absoluteLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+}
+
+var (
+	durationPart = regexp.MustCompile(`(\d+)(w|d|h|m|s)`)
+	clockTime    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+)
+
+// Parse converts a natural-language date such as "tomorrow", "next fri", "+3d" or "2026-11-01 17:00"
+// into an absolute time relative to now. Dates without a time of day resolve to the end of that day.
+func Parse(input string, now time.Time) (time.Time, error) {
+	value := strings.ToLower(strings.Join(strings.Fields(input), " "))
+	if value == "" {
+		return time.Time{}, ErrInvalidDate
+	}
+
+	if strings.HasPrefix(value, "+") {
+		duration, err := ParseDuration(value[1:])
+		if err != nil {
+			return time.Time{}, ErrInvalidDate
+		}
+		return now.Add(duration), nil
+	}
+
+	// The layouts spell the T and Z of ISO dates in upper case.
+	for _, layout := range absoluteLayouts {
+		if t, err := time.ParseInLocation(layout, strings.ToUpper(value), now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	day, clock := value, ""
+	if i := strings.LastIndex(value, " "); i > 0 {
+		if _, _, ok := parseClock(value[i+1:]); ok {
+			day, clock = value[:i], value[i+1:]
+		}
+	}
+
+	date, ok := parseDay(day, now)
+	if !ok {
+		return time.Time{}, ErrInvalidDate
+	}
+
+	if clock == "" {
+		return EndOfDay(date), nil
+	}
+
+	hour, minute, _ := parseClock(clock)
+	return time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, date.Location()), nil
+}
+
+// ParseDuration parses durations like "30m", "1h30m", "3d" or "2w". A bare number is read as minutes.
+func ParseDuration(input string) (time.Duration, error) {
+	value := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(input), " ", ""))
+	if value == "" {
+		return 0, ErrInvalidDuration
+	}
+
+	if minutes, err := strconv.Atoi(value); err == nil && minutes >= 0 {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+
+	if durationPart.ReplaceAllString(value, "") != "" {
+		return 0, ErrInvalidDuration
+	}
+
+	var total time.Duration
+	for _, match := range durationPart.FindAllStringSubmatch(value, -1) {
+		amount, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "w":
+			total += time.Duration(amount) * 7 * 24 * time.Hour
+		case "d":
+			total += time.Duration(amount) * 24 * time.Hour
+		case "h":
+			total += time.Duration(amount) * time.Hour
+		case "m":
+			total += time.Duration(amount) * time.Minute
+		case "s":
+			total += time.Duration(amount) * time.Second
+		}
+	}
+
+	return total, nil
+}
+
+// FormatDuration renders a duration compactly, e.g. "1h30m" or "45m".
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d <= 0 {
+		return "0m"
+	}
+
+	hours, minutes := int(d.Hours()), int(d.Minutes())%60
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	if minutes == 0 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dh%dm", hours, minutes)
+}
+
+// StartOfDay returns midnight at the beginning of t's day.
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// EndOfDay returns the last second of t's day.
+func EndOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, t.Location())
+}
+
+// SameDay reports whether a and b fall on the same calendar day in a's location.
+func SameDay(a, b time.Time) bool {
+	b = b.In(a.Location())
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+func parseDay(value string, now time.Time) (time.Time, bool) {
+	switch value {
+	case "today", "tonight", "eod":
+		return now, true
+	case "tomorrow", "tmr":
+		return now.AddDate(0, 0, 1), true
+	case "yesterday":
+		return now.AddDate(0, 0, -1), true
+	case "next week":
+		return nextWeekday(now, time.Monday), true
+	case "next month":
+		return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location()), true
+	case "eow":
+		return nextWeekday(now.AddDate(0, 0, -1), time.Sunday), true
+	case "eom":
+		return time.Date(now.Year(), now.Month()+1, 0, 0, 0, 0, 0, now.Location()), true
+	}
+
+	words := strings.Fields(value)
+	if len(words) == 2 && (words[0] == "next" || words[0] == "this") {
+		if weekday, ok := weekdays[words[1]]; ok {
+			date := nextWeekday(now, weekday)
+			if words[0] == "this" && now.Weekday() == weekday {
+				date = now
+			}
+			return date, true
+		}
+	}
+	if len(words) == 1 {
+		if weekday, ok := weekdays[words[0]]; ok {
+			return nextWeekday(now, weekday), true
+		}
+		if t, err := time.ParseInLocation("2006-01-02", words[0], now.Location()); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// nextWeekday returns the first occurrence of weekday strictly after now.
+func nextWeekday(now time.Time, weekday time.Weekday) time.Time {
+	days := (int(weekday) - int(now.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return now.AddDate(0, 0, days)
+}
+
+func parseClock(value string) (hour, minute int, ok bool) {
+	matches := clockTime.FindStringSubmatch(value)
+	if matches == nil || (matches[2] == "" && matches[3] == "") {
+		return 0, 0, false
+	}
+
+	hour, _ = strconv.Atoi(matches[1])
+	if matches[2] != "" {
+		minute, _ = strconv.Atoi(matches[2])
+	}
+
+	switch matches[3] {
+	case "am":
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 12 {
+			hour += 12
+		}
+	}
+
+	if hour > 23 || minute > 59 {
+		return 0, 0, false
+	}
+
+	return hour, minute, true
+}
@@ -0,0 +1,103 @@
+package dates
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	// A Wednesday morning.
+	now := time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute, second int) time.Time {
+		return time.Date(2026, month, day, hour, minute, second, 0, time.UTC)
+	}
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{input: "today", want: at(10, 14, 23, 59, 59)},
+		{input: "tonight", want: at(10, 14, 23, 59, 59)},
+		{input: "tomorrow", want: at(10, 15, 23, 59, 59)},
+		{input: "tmr", want: at(10, 15, 23, 59, 59)},
+		{input: "yesterday", want: at(10, 13, 23, 59, 59)},
+		{input: "fri", want: at(10, 16, 23, 59, 59)},
+		{input: "next fri", want: at(10, 16, 23, 59, 59)},
+		{input: "wed", want: at(10, 21, 23, 59, 59)},
+		{input: "this wed", want: at(10, 14, 23, 59, 59)},
+		{input: "next wednesday", want: at(10, 21, 23, 59, 59)},
+		{input: "next week", want: at(10, 19, 23, 59, 59)},
+		{input: "next month", want: at(11, 1, 23, 59, 59)},
+		{input: "eow", want: at(10, 18, 23, 59, 59)},
+		{input: "eom", want: at(10, 31, 23, 59, 59)},
+		{input: "tomorrow 9am", want: at(10, 15, 9, 0, 0)},
+		{input: "fri 17:00", want: at(10, 16, 17, 0, 0)},
+		{input: "next mon 12pm", want: at(10, 19, 12, 0, 0)},
+		{input: "today 12am", want: at(10, 14, 0, 0, 0)},
+		{input: "  Next   FRI  ", want: at(10, 16, 23, 59, 59)},
+		{input: "+3d", want: at(10, 17, 10, 30, 0)},
+		{input: "+1h30m", want: at(10, 14, 12, 0, 0)},
+		{input: "2026-11-01", want: at(11, 1, 23, 59, 59)},
+		{input: "2026-11-01 17:00", want: at(11, 1, 17, 0, 0)},
+		{input: "2026-11-01T17:00:30", want: at(11, 1, 17, 0, 30)},
+		{input: "2026-11-01t17:00", want: at(11, 1, 17, 0, 0)},
+		{input: "2026-11-01T17:00:00Z", want: at(11, 1, 17, 0, 0)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := Parse(test.input, now)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", test.input, err)
+			}
+			if !got.Equal(test.want) {
+				t.Errorf("Parse(%q) = %v, want %v", test.input, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	now := time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC)
+
+	for _, input := range []string{"", "  ", "someday", "next year", "+3x", "fri 25:00", "2026-13-01"} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := Parse(input, now); !errors.Is(err, ErrInvalidDate) {
+				t.Errorf("Parse(%q) returned error %v, want %v", input, err, ErrInvalidDate)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{input: "45", want: 45 * time.Minute},
+		{input: "30m", want: 30 * time.Minute},
+		{input: "1h30m", want: 90 * time.Minute},
+		{input: "1h 30m", want: 90 * time.Minute},
+		{input: "3d", want: 72 * time.Hour},
+		{input: "2w", want: 14 * 24 * time.Hour},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := ParseDuration(test.input)
+			if err != nil {
+				t.Fatalf("ParseDuration(%q) returned error: %v", test.input, err)
+			}
+			if got != test.want {
+				t.Errorf("ParseDuration(%q) = %v, want %v", test.input, got, test.want)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "soon", "3x", "-5"} {
+		if _, err := ParseDuration(input); !errors.Is(err, ErrInvalidDuration) {
+			t.Errorf("ParseDuration(%q) returned error %v, want %v", input, err, ErrInvalidDuration)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package editor
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+var (
+	ErrNoEditor = errors.New("no editor found, set $VISUAL or $EDITOR")
+)
+
+// Command returns the user's preferred editor from $VISUAL or $EDITOR, falling back to vi.
+// The value may include arguments, e.g. "code --wait".
+func Command() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// Edit writes content to a temporary file with the given pattern (e.g. "task-*.md"), opens it in
+// the user's editor and returns the saved content once the editor exits.
+func Edit(pattern, content string) (string, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	args := Command()
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return "", ErrNoEditor
+	}
+
+	cmd := exec.Command(path, append(args[1:], file.Name())...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+
+	edited, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(edited), nil
+}
@@ -5,6 +5,7 @@ go 1.23.1
 require (
 	github.com/goravel/framework v1.15.2
 	github.com/mattn/go-sqlite3 v1.14.24
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -21,6 +21,7 @@ func main() {
 
 	cli.Register(kernel.Commands())
 	if err := cli.Run(os.Args, false); err != nil {
-		color.Red().Println(err)
+		color.Errorln(err)
+		os.Exit(1)
 	}
 }
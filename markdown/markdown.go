@@ -0,0 +1,107 @@
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	heading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	bullet      = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	checkbox    = regexp.MustCompile(`^\[( |x|X)\]\s+(.*)$`)
+	numbered    = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	rule        = regexp.MustCompile(`^\s*((-\s*){3,}|(\*\s*){3,}|(_\s*){3,})$`)
+	inlineCode  = regexp.MustCompile("`([^`]+)`")
+	bold        = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	italic      = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b`)
+	link        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	placeholder = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// Render converts Markdown into text with color tags for the terminal. It supports the subset that is
+// useful in task notes: headings, lists and checklists, block quotes, code, rules, links and emphasis.
+func Render(source string) string {
+	var out []string
+	inFence := false
+
+	for _, line := range strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			out = append(out, "<fg=yellow>    "+Escape(line)+"</>")
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			out = append(out, "")
+		case rule.MatchString(line):
+			out = append(out, "<fg=gray>"+strings.Repeat("─", 40)+"</>")
+		case heading.MatchString(trimmed):
+			matches := heading.FindStringSubmatch(trimmed)
+			text := inline(matches[2])
+			if len(matches[1]) == 1 {
+				text = strings.ToUpper(text)
+			}
+			out = append(out, "<fg=cyan;op=bold>"+text+"</>")
+		case strings.HasPrefix(trimmed, ">"):
+			out = append(out, "<fg=gray>│ "+inline(strings.TrimSpace(strings.TrimPrefix(trimmed, ">")))+"</>")
+		case bullet.MatchString(line):
+			matches := bullet.FindStringSubmatch(line)
+			indent, item := indentation(matches[1]), matches[2]
+			if box := checkbox.FindStringSubmatch(item); box != nil {
+				if box[1] == " " {
+					out = append(out, indent+"<fg=gray>☐</> "+inline(box[2]))
+				} else {
+					out = append(out, indent+"<fg=green>☑</> <fg=gray>"+inline(box[2])+"</>")
+				}
+				continue
+			}
+			out = append(out, indent+"<fg=cyan>•</> "+inline(item))
+		case numbered.MatchString(line):
+			matches := numbered.FindStringSubmatch(line)
+			out = append(out, indentation(matches[1])+"<fg=cyan>"+matches[2]+".</> "+inline(matches[3]))
+		default:
+			out = append(out, inline(trimmed))
+		}
+	}
+
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+// inline renders emphasis, code spans and links within a single line. Code spans are set aside
+// first so that their contents are shown verbatim.
+func inline(text string) string {
+	text = Escape(text)
+
+	var spans []string
+	text = inlineCode.ReplaceAllStringFunc(text, func(match string) string {
+		spans = append(spans, "<fg=yellow>"+inlineCode.FindStringSubmatch(match)[1]+"</>")
+		return "\x00" + strconv.Itoa(len(spans)-1) + "\x00"
+	})
+
+	text = link.ReplaceAllString(text, "<op=underscore>$1</> <fg=gray>($2)</>")
+	text = bold.ReplaceAllString(text, "<op=bold>$1$2</>")
+	text = italic.ReplaceAllString(text, "<op=italic>$1$2</>")
+
+	return placeholder.ReplaceAllStringFunc(text, func(match string) string {
+		index, _ := strconv.Atoi(placeholder.FindStringSubmatch(match)[1])
+		return spans[index]
+	})
+}
+
+// Escape keeps text that looks like a color tag, such as <fg=red>, from being rendered as one by putting
+// an invisible word joiner after every "<".
+func Escape(text string) string {
+	return strings.ReplaceAll(text, "<", "<\u2060")
+}
+
+// indentation renders nested list items two spaces deeper per level.
+func indentation(whitespace string) string {
+	level := len(strings.ReplaceAll(whitespace, "\t", "  ")) / 2
+	return strings.Repeat("  ", level) + "  "
+}
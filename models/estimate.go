@@ -0,0 +1,62 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kkumar-gcc/todo/dates"
+)
+
+var ErrInvalidEstimate = errors.New("unrecognized estimate, try e.g. 30m, 2h, 1d or 3pt")
+
+// pointSuffixes are the units that mark an estimate as story points rather than a duration.
+var pointSuffixes = []string{"points", "point", "pts", "pt", "sp", "p"}
+
+// Estimate is the expected effort of a task, either as a duration or in story points. The zero value
+// means the task has not been estimated.
+type Estimate struct {
+	Duration time.Duration `json:"duration,omitempty"`
+	Points   int           `json:"points,omitempty"`
+}
+
+// ParseEstimate parses an estimate such as "30m", "1h30m" or "3pt". An empty value or "none" is no estimate.
+func ParseEstimate(input string) (Estimate, error) {
+	value := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(input), " ", ""))
+	if value == "" || value == "none" {
+		return Estimate{}, nil
+	}
+
+	for _, suffix := range pointSuffixes {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			points, err := strconv.Atoi(number)
+			if err != nil || points < 0 {
+				return Estimate{}, ErrInvalidEstimate
+			}
+			return Estimate{Points: points}, nil
+		}
+	}
+
+	duration, err := dates.ParseDuration(value)
+	if err != nil {
+		return Estimate{}, ErrInvalidEstimate
+	}
+	return Estimate{Duration: duration}, nil
+}
+
+// IsZero reports whether the task has no estimate.
+func (e Estimate) IsZero() bool {
+	return e.Duration == 0 && e.Points == 0
+}
+
+// String renders the estimate in a form that ParseEstimate accepts, or an empty string for no estimate.
+func (e Estimate) String() string {
+	if e.Points > 0 {
+		return strconv.Itoa(e.Points) + "pt"
+	}
+	if e.Duration > 0 {
+		return dates.FormatDuration(e.Duration)
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// Measures of the actual effort an EstimateReport compares estimates with.
+const (
+	ActualTracked = "tracked" // The time tracked on the task
+	ActualElapsed = "elapsed" // The time from creating the task to completing it
+)
+
+// EstimateReportOptions selects the tasks of an EstimateReport and how their actual effort is measured.
+type EstimateReportOptions struct {
+	From   time.Time // Only tasks completed at or after this moment
+	To     time.Time // Only tasks completed before this moment
+	Actual string    // Use constants: models.ActualTracked, models.ActualElapsed
+}
+
+// EstimateReport compares the estimates of the tasks completed in a period with the effort they took, per tag.
+type EstimateReport struct {
+	EstimateReportOptions
+	Groups  []EstimateAccuracy
+	Total   EstimateAccuracy // Each task is counted once, even when it has several tags
+	Skipped int              // Estimated tasks left out because no time was tracked on them
+}
+
+// EstimateAccuracy totals the estimated and actual effort of a group of tasks. Tasks estimated as a
+// duration and in story points are totalled apart, since the two cannot be compared.
+type EstimateAccuracy struct {
+	Name        string
+	Tasks       int // Number of tasks estimated as a duration
+	Estimated   time.Duration
+	Actual      time.Duration // Actual effort of the tasks estimated as a duration
+	PointTasks  int           // Number of tasks estimated in story points
+	Points      int
+	PointActual time.Duration // Actual effort of the tasks estimated in story points
+}
+
+// Add counts a task and the effort it actually took.
+func (a *EstimateAccuracy) Add(task Task, actual time.Duration) {
+	if task.Estimate.Points > 0 {
+		a.PointTasks++
+		a.Points += task.Estimate.Points
+		a.PointActual += actual
+		return
+	}
+
+	a.Tasks++
+	a.Estimated += task.Estimate.Duration
+	a.Actual += actual
+}
+
+// Ratio returns the actual effort divided by the estimated effort, which is above 1 when the tasks took
+// longer than estimated. It is zero when no task was estimated as a duration.
+func (a EstimateAccuracy) Ratio() float64 {
+	if a.Estimated == 0 {
+		return 0
+	}
+	return float64(a.Actual) / float64(a.Estimated)
+}
+
+// PerPoint returns the average effort of a story point, or zero when no task was estimated in points.
+func (a EstimateAccuracy) PerPoint() time.Duration {
+	if a.Points == 0 {
+		return 0
+	}
+	return a.PointActual / time.Duration(a.Points)
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Operation is a journaled command that changed one or more tasks and can be undone as a whole.
+type Operation struct {
+	ID          int        `json:"id"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UndoneAt    *time.Time `json:"undone_at,omitempty"`
+	Redoable    bool       `json:"redoable"`
+	EventCount  int        `json:"event_count"` // Number of task changes made by the operation, derived
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Pomodoro is a focused work session on a task. Sessions cut short are kept as not completed.
+type Pomodoro struct {
+	ID        int           `json:"id"`
+	TaskID    int           `json:"task_id"`
+	StartedAt time.Time     `json:"started_at"`
+	EndedAt   time.Time     `json:"ended_at"`
+	Planned   time.Duration `json:"planned"` // Length the session was meant to run
+	Completed bool          `json:"completed"`
+}
+
+// PomodoroCount is the number of focus sessions spent on a task.
+type PomodoroCount struct {
+	Completed   int `json:"completed"`
+	Interrupted int `json:"interrupted"`
+}
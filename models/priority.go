@@ -0,0 +1,152 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kkumar-gcc/todo/constants"
+)
+
+// PriorityLevel is one step of a PriorityScale.
+type PriorityLevel struct {
+	Name  string `yaml:"name"`  // Unique name used on the command line, e.g. "high" or "P0"
+	Label string `yaml:"label"` // Shown in listings, defaults to the name
+	Color string `yaml:"color"`
+}
+
+// PriorityScale is the ordered set of priorities a task can have. Tasks store the rank of their
+// priority: 1 for the lowest level up to the number of levels for the highest.
+type PriorityScale struct {
+	Default string          `yaml:"default"` // Name of the level given to new tasks, defaults to the lowest
+	Levels  []PriorityLevel `yaml:"levels"`  // From the lowest priority to the highest
+}
+
+// DefaultPriorityScale returns the low, medium and high scale used when none is configured.
+func DefaultPriorityScale() PriorityScale {
+	return PriorityScale{
+		Levels: []PriorityLevel{
+			{Name: "low", Label: "Low", Color: "green"},
+			{Name: "medium", Label: "Medium", Color: "yellow"},
+			{Name: "high", Label: "High", Color: "red"},
+		},
+	}
+}
+
+// Validate checks that the scale has levels with unique names and known colors, and a default among them.
+func (s PriorityScale) Validate() error {
+	if len(s.Levels) == 0 {
+		return errors.New("priorities.levels needs at least one level")
+	}
+
+	seen := make(map[string]bool)
+	for i, level := range s.Levels {
+		name := strings.ToLower(strings.TrimSpace(level.Name))
+		if name == "" {
+			return fmt.Errorf("priorities.levels[%d] has no name", i)
+		}
+		if seen[name] {
+			return fmt.Errorf("priorities.levels has more than one level named %q", level.Name)
+		}
+		seen[name] = true
+		if level.Color != "" && !slices.Contains(constants.Colors, level.Color) {
+			return fmt.Errorf("priorities.levels[%d].color must be one of: %s", i, strings.Join(constants.Colors, ", "))
+		}
+	}
+
+	if s.Default != "" && s.Rank(s.Default) == 0 {
+		return fmt.Errorf("priorities.default must be one of: %s", strings.Join(s.Names(), ", "))
+	}
+	return nil
+}
+
+// Level returns the level with the given rank, or nil if the rank is outside the scale.
+func (s PriorityScale) Level(rank int) *PriorityLevel {
+	if rank < 1 || rank > len(s.Levels) {
+		return nil
+	}
+	return &s.Levels[rank-1]
+}
+
+// Rank returns the rank of the level with the given name, ignoring case, or zero if there is none.
+func (s PriorityScale) Rank(name string) int {
+	name = strings.TrimSpace(name)
+	for i, level := range s.Levels {
+		if strings.EqualFold(level.Name, name) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Resolve looks a priority up by its name, falling back to its rank. It returns zero when neither matches.
+func (s PriorityScale) Resolve(nameOrRank string) int {
+	if rank := s.Rank(nameOrRank); rank != 0 {
+		return rank
+	}
+	if rank, err := strconv.Atoi(strings.TrimSpace(nameOrRank)); err == nil && s.Level(rank) != nil {
+		return rank
+	}
+	return 0
+}
+
+// DefaultRank returns the rank given to tasks created without a priority.
+func (s PriorityScale) DefaultRank() int {
+	if rank := s.Rank(s.Default); rank != 0 {
+		return rank
+	}
+	return 1
+}
+
+// Names returns the names of every level, from the lowest priority to the highest.
+func (s PriorityScale) Names() []string {
+	names := make([]string, len(s.Levels))
+	for i, level := range s.Levels {
+		names[i] = level.Name
+	}
+	return names
+}
+
+// Label returns the label of the level with the given rank, or the rank itself if it is outside the scale.
+func (s PriorityScale) Label(rank int) string {
+	level := s.Level(rank)
+	if level == nil {
+		return strconv.Itoa(rank)
+	}
+	if level.Label == "" {
+		return level.Name
+	}
+	return level.Label
+}
+
+// Color returns the color of the level with the given rank, or gray if the rank is outside the scale.
+func (s PriorityScale) Color(rank int) string {
+	level := s.Level(rank)
+	if level == nil {
+		return "gray"
+	}
+	if level.Color == "" {
+		return "white"
+	}
+	return level.Color
+}
+
+// RemapFrom returns the rank in this scale of each rank in the scale from. A level keeps its place when
+// both scales have a level of that name, and is otherwise moved to the same relative height. Ranks
+// outside the scale from are treated as its lowest or highest level.
+func (s PriorityScale) RemapFrom(from PriorityScale) func(rank int) int {
+	return func(rank int) int {
+		rank = min(max(rank, 1), len(from.Levels))
+		if to := s.Rank(from.Levels[rank-1].Name); to != 0 {
+			return to
+		}
+		if len(from.Levels) == 1 {
+			return s.DefaultRank()
+		}
+		height := float64(rank-1) / float64(len(from.Levels)-1)
+		return 1 + int(math.Round(height*float64(len(s.Levels)-1)))
+	}
+}
@@ -0,0 +1,91 @@
+package models
+
+import "testing"
+
+// fivePriorities is a P4 to P0 scale, from the lowest priority to the highest.
+var fivePriorities = PriorityScale{
+	Default: "P3",
+	Levels:  []PriorityLevel{{Name: "P4"}, {Name: "P3"}, {Name: "P2"}, {Name: "P1"}, {Name: "P0"}},
+}
+
+func TestPriorityScaleRemapFrom(t *testing.T) {
+	tests := []struct {
+		name string
+		from PriorityScale
+		to   PriorityScale
+		want map[int]int
+	}{
+		{
+			name: "same relative height",
+			from: DefaultPriorityScale(),
+			to:   fivePriorities,
+			want: map[int]int{1: 1, 2: 3, 3: 5},
+		},
+		{
+			name: "fewer levels",
+			from: fivePriorities,
+			to:   DefaultPriorityScale(),
+			want: map[int]int{1: 1, 2: 2, 3: 2, 4: 3, 5: 3},
+		},
+		{
+			name: "ranks outside the scale",
+			from: DefaultPriorityScale(),
+			to:   fivePriorities,
+			want: map[int]int{-1: 1, 0: 1, 7: 5},
+		},
+		{
+			name: "names kept",
+			from: DefaultPriorityScale(),
+			to:   PriorityScale{Levels: []PriorityLevel{{Name: "someday"}, {Name: "LOW"}, {Name: "medium"}, {Name: "high"}, {Name: "urgent"}}},
+			want: map[int]int{1: 2, 2: 3, 3: 4},
+		},
+		{
+			name: "single level",
+			from: PriorityScale{Levels: []PriorityLevel{{Name: "normal"}}},
+			to:   fivePriorities,
+			want: map[int]int{1: 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			remap := test.to.RemapFrom(test.from)
+			for rank, want := range test.want {
+				if got := remap(rank); got != want {
+					t.Errorf("remap(%d) = %d, want %d", rank, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPriorityScaleValidate(t *testing.T) {
+	if err := fivePriorities.Validate(); err != nil {
+		t.Errorf("Validate returned error for a valid scale: %v", err)
+	}
+
+	invalid := map[string]PriorityScale{
+		"no levels":       {},
+		"unnamed level":   {Levels: []PriorityLevel{{Name: " "}}},
+		"duplicate names": {Levels: []PriorityLevel{{Name: "high"}, {Name: "HIGH"}}},
+		"unknown color":   {Levels: []PriorityLevel{{Name: "high", Color: "orange"}}},
+		"unknown default": {Default: "urgent", Levels: []PriorityLevel{{Name: "high"}}},
+	}
+	for name, scale := range invalid {
+		if err := scale.Validate(); err == nil {
+			t.Errorf("Validate accepted a scale with %s", name)
+		}
+	}
+}
+
+func TestPriorityScaleResolve(t *testing.T) {
+	tests := map[string]int{"p1": 4, " P0 ": 5, "2": 2, "6": 0, "urgent": 0}
+	for input, want := range tests {
+		if got := fivePriorities.Resolve(input); got != want {
+			t.Errorf("Resolve(%q) = %d, want %d", input, got, want)
+		}
+	}
+	if rank := fivePriorities.DefaultRank(); rank != 2 {
+		t.Errorf("DefaultRank() = %d, want 2", rank)
+	}
+}
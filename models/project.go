@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type Project struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Color       string    `json:"color"` // One of constants.Colors, or empty for the default color
+	Archived    bool      `json:"archived"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	TaskCount      int `json:"task_count"`      // Number of tasks in the project, derived
+	CompletedCount int `json:"completed_count"` // Number of completed tasks in the project, derived
+}
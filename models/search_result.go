@@ -0,0 +1,12 @@
+package models
+
+// Markers placed around the matched terms in a SearchResult snippet.
+const (
+	HighlightStart = "\x02"
+	HighlightEnd   = "\x03"
+)
+
+type SearchResult struct {
+	Task
+	Snippet string `json:"snippet"` // Excerpt of the best matching field, with matches between HighlightStart and HighlightEnd
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"slices"
+	"strings"
+)
+
+// Categories of a Status, which decide how the rest of the application treats tasks in that status.
+const (
+	CategoryOpen   = "open"   // Not started yet
+	CategoryActive = "active" // Being worked on
+	CategoryDone   = "done"   // Finished, whether it was completed or not
+)
+
+// Categories lists every status category, in the order a task usually goes through them.
+var Categories = []string{CategoryOpen, CategoryActive, CategoryDone}
+
+// Status is a step of the workflow a task goes through.
+type Status struct {
+	ID             int
+	Name           string // Unique name used on the command line, e.g. "in-progress"
+	Label          string
+	Color          string
+	Category       string // Use constants: models.CategoryOpen, models.CategoryActive, models.CategoryDone
+	RequiresReason bool   // Whether moving a task to this status needs a reason, as for cancelling it
+	Position       int
+	Transitions    []int // IDs of the statuses a task in this status can move to
+}
+
+// IsDone reports whether tasks in this status are finished.
+func (s *Status) IsDone() bool {
+	return s.Category == CategoryDone
+}
+
+// Workflow is the set of statuses a task can be in and the moves allowed between them.
+type Workflow struct {
+	Statuses []Status // Ordered by position
+}
+
+// Status returns the status with the given ID, or nil if there is none.
+func (w *Workflow) Status(id int) *Status {
+	for i := range w.Statuses {
+		if w.Statuses[i].ID == id {
+			return &w.Statuses[i]
+		}
+	}
+	return nil
+}
+
+// StatusByName returns the status with the given name, ignoring case, or nil if there is none.
+func (w *Workflow) StatusByName(name string) *Status {
+	name = strings.TrimSpace(name)
+	for i := range w.Statuses {
+		if strings.EqualFold(w.Statuses[i].Name, name) {
+			return &w.Statuses[i]
+		}
+	}
+	return nil
+}
+
+// Names returns the names of every status, in workflow order.
+func (w *Workflow) Names() []string {
+	names := make([]string, len(w.Statuses))
+	for i, status := range w.Statuses {
+		names[i] = status.Name
+	}
+	return names
+}
+
+// Targets returns the statuses a task in the given status can move to, in workflow order.
+func (w *Workflow) Targets(id int) []Status {
+	from := w.Status(id)
+	if from == nil {
+		return nil
+	}
+
+	var targets []Status
+	for _, status := range w.Statuses {
+		if slices.Contains(from.Transitions, status.ID) {
+			targets = append(targets, status)
+		}
+	}
+	return targets
+}
+
+// CanTransition reports whether a task may move from one status to another. Staying in the same status
+// is always allowed, and so is leaving a status that is not part of the workflow.
+func (w *Workflow) CanTransition(from, to int) bool {
+	if from == to {
+		return true
+	}
+	status := w.Status(from)
+	return status == nil || slices.Contains(status.Transitions, to)
+}
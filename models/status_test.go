@@ -0,0 +1,36 @@
+package models
+
+import "testing"
+
+func TestWorkflowCanTransition(t *testing.T) {
+	workflow := &Workflow{Statuses: []Status{
+		{ID: 1, Name: "todo", Category: CategoryOpen, Transitions: []int{2}},
+		{ID: 2, Name: "doing", Category: CategoryActive, Transitions: []int{1, 3}},
+		{ID: 3, Name: "done", Category: CategoryDone},
+	}}
+
+	tests := []struct {
+		from, to int
+		want     bool
+	}{
+		{from: 1, to: 2, want: true},
+		{from: 1, to: 3, want: false},
+		{from: 2, to: 3, want: true},
+		{from: 3, to: 1, want: false},
+		{from: 3, to: 3, want: true},
+		{from: 9, to: 1, want: true},
+	}
+
+	for _, test := range tests {
+		if got := workflow.CanTransition(test.from, test.to); got != test.want {
+			t.Errorf("CanTransition(%d, %d) = %v, want %v", test.from, test.to, got, test.want)
+		}
+	}
+
+	if targets := workflow.Targets(2); len(targets) != 2 || targets[0].Name != "todo" || targets[1].Name != "done" {
+		t.Errorf("Targets(2) = %v, want todo and done", targets)
+	}
+	if status := workflow.StatusByName(" DOING "); status == nil || status.ID != 2 {
+		t.Errorf("StatusByName(%q) = %v, want doing", " DOING ", status)
+	}
+}
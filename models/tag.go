@@ -0,0 +1,21 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+type Tag struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	TaskCount int    `json:"task_count"` // Number of tasks with this tag, derived
+}
+
+// NormalizeTag lowercases a tag, trims it and joins its words with single dashes, so "Work", " work" and
+// "work" are the same tag, as are "big work" and "big--work". Commas separate words too, as tags are
+// listed separated by commas.
+func NormalizeTag(tag string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(tag), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == '-'
+	}), "-")
+}
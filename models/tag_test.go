@@ -0,0 +1,29 @@
+package models
+
+import "testing"
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "work", want: "work"},
+		{tag: "Work", want: "work"},
+		{tag: "  work ", want: "work"},
+		{tag: "big work", want: "big-work"},
+		{tag: "Big \t Work", want: "big-work"},
+		{tag: "big--work", want: "big-work"},
+		{tag: "-big-work-", want: "big-work"},
+		{tag: "big,work", want: "big-work"},
+		{tag: " , ", want: ""},
+		{tag: "", want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.tag, func(t *testing.T) {
+			if got := NormalizeTag(test.tag); got != test.want {
+				t.Errorf("NormalizeTag(%q) = %q, want %q", test.tag, got, test.want)
+			}
+		})
+	}
+}
@@ -3,11 +3,71 @@ package models
 import "time"
 
 type Task struct {
-	ID          int        `json:"id"`
-	Title       string     `json:"title"`
-	Status      int        `json:"status"` // Use constants: constants.StatusPending, constants.StatusInProgress, constants.StatusCompleted
-	CreatedAt   time.Time  `json:"created_at"`
-	CompletedAt *time.Time `json:"completed_at,omitempty"`
-	Priority    int        `json:"priority"` // Use constants: constants.PriorityLow, constants.PriorityMedium, constants.PriorityHigh
-	Tags        string     `json:"tags"`     // Tags for categorization
+	ID           int        `json:"id"`
+	Title        string     `json:"title"`
+	Notes        string     `json:"notes,omitempty"`         // Long-form Markdown notes
+	Status       int        `json:"status"`                  // ID of a Status of the workflow, such as constants.StatusPending
+	StatusReason string     `json:"status_reason,omitempty"` // Why the task was moved to its status, required by some statuses such as cancelled
+	CreatedAt    time.Time  `json:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	Priority     int        `json:"priority"` // Rank in the configured PriorityScale, 1 being the lowest
+	Tags         []string   `json:"tags"`     // Normalized tags for categorization
+	DueAt        *time.Time `json:"due_at,omitempty"`
+	Recurrence   string     `json:"recurrence,omitempty"` // RRULE describing how the task repeats, empty if it does not
+	ParentID     *int       `json:"parent_id,omitempty"`
+	ProjectID    *int       `json:"project_id,omitempty"`
+	ArchivedAt   *time.Time `json:"archived_at,omitempty"`   // When the completed task was archived, nil if it is active
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`    // When the task was moved to the trash, nil if it was not
+	Estimate     Estimate   `json:"estimate"`                // Expected effort, the zero value if the task is not estimated
+	WaitUntil    *time.Time `json:"wait_until,omitempty"`    // The task is snoozed and hidden from listings until then, nil if it is not
+	ResurfacedAt *time.Time `json:"resurfaced_at,omitempty"` // When the task came back from being snoozed, kept from versions that saved it
+
+	SubtaskCount      int           `json:"subtask_count"`        // Number of direct subtasks, derived
+	CompletedSubtasks int           `json:"completed_subtasks"`   // Number of completed direct subtasks, derived
+	BlockedBy         []int         `json:"blocked_by,omitempty"` // IDs of open tasks this task depends on, derived
+	Project           string        `json:"project,omitempty"`    // Name of the task's project, derived
+	StatusLabel       string        `json:"status_label"`         // Label of the task's status, derived
+	StatusColor       string        `json:"status_color"`         // Color of the task's status, derived
+	StatusCategory    string        `json:"status_category"`      // Category of the task's status, derived
+	TrackedTime       time.Duration `json:"tracked_time"`         // Total time logged on the task, derived
+	Tracking          bool          `json:"tracking"`             // Whether a timer is running on the task, derived
+	Urgency           float64       `json:"urgency"`              // How urgent the task is, computed from UrgencyCoefficients
+}
+
+// IsDone reports whether the task's status is in the done category.
+func (t *Task) IsDone() bool {
+	return t.StatusCategory == CategoryDone
+}
+
+// IsOverdue reports whether the task has a due date in the past and is not yet done.
+func (t *Task) IsOverdue(now time.Time) bool {
+	return t.DueAt != nil && t.DueAt.Before(now) && !t.IsDone()
+}
+
+// IsWaiting reports whether the task is snoozed until a moment after now.
+func (t *Task) IsWaiting(now time.Time) bool {
+	return t.WaitUntil != nil && t.WaitUntil.After(now)
+}
+
+// Resurfaced returns when the task came back from being snoozed, or nil when it did not or was updated since.
+// A snooze that ends is not saved until the task is next updated, which clears it.
+func (t *Task) Resurfaced(now time.Time) *time.Time {
+	if t.ResurfacedAt != nil {
+		return t.ResurfacedAt
+	}
+	if t.WaitUntil != nil && !t.WaitUntil.After(now) {
+		return t.WaitUntil
+	}
+	return nil
+}
+
+// IsBlocked reports whether the task depends on tasks that are still open.
+func (t *Task) IsBlocked() bool {
+	return len(t.BlockedBy) > 0
+}
+
+// TaskNode is a task to create along with its subtasks, which are created under it.
+type TaskNode struct {
+	Task     *Task
+	Subtasks []TaskNode
 }
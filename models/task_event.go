@@ -0,0 +1,89 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of TaskEvent.
+const (
+	EventCreated  = "created"
+	EventUpdated  = "updated"
+	EventDeleted  = "deleted"  // Moved to the trash
+	EventRestored = "restored" // Taken out of the trash
+	EventPurged   = "purged"   // Permanently deleted
+)
+
+// TaskEvent is an entry in the append-only audit trail of a task.
+type TaskEvent struct {
+	ID        int           `json:"id"`
+	TaskID    int           `json:"task_id"`
+	Type      string        `json:"type"`  // Use constants: models.EventCreated, models.EventUpdated, models.EventDeleted, ...
+	Title     string        `json:"title"` // Title of the task when the event happened
+	Changes   []FieldChange `json:"changes"`
+	Actor     string        `json:"actor"` // Name of the user who made the change
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// FieldChange is the old and new value of a single task field. Empty values mean the field was unset.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// DiffTasks lists the fields that differ between two versions of a task. A nil old task lists every
+// field set on the new one, and a nil new task every field set on the old one.
+func DiffTasks(old, new *Task) []FieldChange {
+	oldFields, newFields := taskFields(old), taskFields(new)
+
+	var changes []FieldChange
+	for i, field := range taskFieldNames {
+		if oldFields[i] != newFields[i] {
+			changes = append(changes, FieldChange{Field: field, Old: oldFields[i], New: newFields[i]})
+		}
+	}
+	return changes
+}
+
+var taskFieldNames = []string{"title", "status", "status_reason", "priority", "tags", "due_at", "recurrence", "parent_id", "project_id", "completed_at", "notes", "archived_at", "estimate", "wait_until", "resurfaced_at"}
+
+// taskFields returns the audited fields of a task in the order of taskFieldNames.
+func taskFields(task *Task) []string {
+	if task == nil {
+		return make([]string, len(taskFieldNames))
+	}
+
+	return []string{
+		task.Title,
+		strconv.Itoa(task.Status),
+		task.StatusReason,
+		strconv.Itoa(task.Priority),
+		strings.Join(task.Tags, ","),
+		formatTime(task.DueAt),
+		task.Recurrence,
+		formatID(task.ParentID),
+		formatID(task.ProjectID),
+		formatTime(task.CompletedAt),
+		task.Notes,
+		formatTime(task.ArchivedAt),
+		task.Estimate.String(),
+		formatTime(task.WaitUntil),
+		formatTime(task.ResurfacedAt),
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatID(id *int) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.Itoa(*id)
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// TaskFilter narrows down and orders the tasks returned by a listing.
+type TaskFilter struct {
+	Status       int           // Only tasks with this status, or any status when zero
+	Priority     int           // Only tasks with this priority, or any priority when zero
+	Sort         string        // One of: status, priority, due, created, deleted, urgency
+	DueBefore    *time.Time    // Only tasks due before this moment
+	Overdue      bool          // Only open tasks whose due date has passed
+	ProjectID    int           // Only tasks in this project; when zero, tasks in archived projects are hidden outside the trash
+	AnyTags      []string      // Only tasks with at least one of these tags
+	AllTags      []string      // Only tasks with every one of these tags
+	NoTags       []string      // Only tasks with none of these tags
+	Deleted      bool          // Only tasks in the trash; otherwise trashed tasks are hidden
+	Archived     bool          // Only archived tasks; otherwise archived tasks are hidden outside the trash
+	WithArchived bool          // Include archived tasks alongside active ones
+	Waiting      bool          // Only snoozed tasks; otherwise snoozed tasks are hidden outside the trash
+	WithWaiting  bool          // Include snoozed tasks alongside the others
+	MaxEstimate  time.Duration // Only tasks estimated to take at most this long, or any task when zero
+}
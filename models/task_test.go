@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskSnooze(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	earlier, later := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		task       Task
+		waiting    bool
+		resurfaced *time.Time
+	}{
+		{name: "not snoozed", task: Task{}},
+		{name: "snoozed", task: Task{WaitUntil: &later}, waiting: true},
+		{name: "snooze ended", task: Task{WaitUntil: &earlier}, resurfaced: &earlier},
+		{name: "snooze ends now", task: Task{WaitUntil: &now}, resurfaced: &now},
+		{name: "resurfaced earlier", task: Task{ResurfacedAt: &earlier}, resurfaced: &earlier},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.task.IsWaiting(now); got != test.waiting {
+				t.Errorf("IsWaiting = %v, want %v", got, test.waiting)
+			}
+			got := test.task.Resurfaced(now)
+			if (got == nil) != (test.resurfaced == nil) || got != nil && !got.Equal(*test.resurfaced) {
+				t.Errorf("Resurfaced = %v, want %v", got, test.resurfaced)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package models
+
+// Template is a reusable set of tasks, read from a YAML file in the templates directory. Titles, notes
+// and due dates are Go templates that can refer to the template's variables, e.g. "Release {{.Version}}".
+type Template struct {
+	Name        string         `yaml:"-"` // The file name without its extension
+	Path        string         `yaml:"-"`
+	Description string         `yaml:"description"`
+	Project     string         `yaml:"project"`   // Name or ID of the project the tasks are added to
+	Variables   []string       `yaml:"variables"` // Variables that must be given when the template is applied
+	Tasks       []TemplateTask `yaml:"tasks"`
+}
+
+// TemplateTask describes one task created by a template, along with its subtasks.
+type TemplateTask struct {
+	Title    string         `yaml:"title"`
+	Notes    string         `yaml:"notes"`
+	Tags     []string       `yaml:"tags"`
+	Priority string         `yaml:"priority"` // Name or rank of the priority, defaults to the configured default
+	Due      string         `yaml:"due"`      // Relative to when the template is applied, e.g. "+3d" or "friday"
+	Estimate string         `yaml:"estimate"` // A duration such as "2h" or story points such as "3pt"
+	Subtasks []TemplateTask `yaml:"subtasks"`
+}
+
+// Count returns the number of tasks the template creates, subtasks included.
+func (t *Template) Count() int {
+	return countTemplateTasks(t.Tasks)
+}
+
+func countTemplateTasks(tasks []TemplateTask) int {
+	count := len(tasks)
+	for _, task := range tasks {
+		count += countTemplateTasks(task.Subtasks)
+	}
+	return count
+}
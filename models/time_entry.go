@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// TimeEntry is a span of time spent on a task, either tracked with a timer or logged by hand.
+type TimeEntry struct {
+	ID        int        `json:"id"`
+	TaskID    int        `json:"task_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"` // Nil while the timer is running
+	Note      string     `json:"note,omitempty"`
+
+	Task    string   `json:"task"`              // Title of the task, derived
+	Tags    []string `json:"tags"`              // Tags of the task, derived
+	Project string   `json:"project,omitempty"` // Name of the task's project, derived
+}
+
+// IsRunning reports whether the entry is a timer that has not been stopped yet.
+func (e *TimeEntry) IsRunning() bool {
+	return e.EndedAt == nil
+}
+
+// Duration returns the length of the entry, counting a running timer up to now.
+func (e *TimeEntry) Duration(now time.Time) time.Duration {
+	if e.EndedAt != nil {
+		now = *e.EndedAt
+	}
+	return now.Sub(e.StartedAt)
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Groupings of a TimeReport.
+const (
+	ReportByTask    = "task"
+	ReportByTag     = "tag"
+	ReportByProject = "project"
+	ReportByDay     = "day"
+	ReportByWeek    = "week"
+)
+
+// Rounding modes of a TimeReport.
+const (
+	RoundUp      = "up"
+	RoundDown    = "down"
+	RoundNearest = "nearest"
+)
+
+// TimeReportOptions selects the time entries of a TimeReport and how they are totalled.
+type TimeReportOptions struct {
+	From     time.Time     // Only entries that started at or after this moment
+	To       time.Time     // Only entries that started before this moment
+	GroupBy  string        // Use constants: models.ReportByTask, models.ReportByTag, ...
+	Round    time.Duration // Round every row to a multiple of this, or not at all when zero
+	Rounding string        // Use constants: models.RoundUp, models.RoundDown, models.RoundNearest
+}
+
+// TimeReport totals the time tracked in a period, grouped by task, tag, project, day or week.
+type TimeReport struct {
+	TimeReportOptions
+	Groups []TimeReportGroup
+	Total  time.Duration // Each entry is counted once, even when it appears in several groups
+}
+
+// TimeReportGroup is a group of a TimeReport with its subtotal.
+type TimeReportGroup struct {
+	Name  string
+	Rows  []TimeReportRow
+	Total time.Duration
+}
+
+// TimeReportRow is the rounded time spent on a task within a group. When a report is grouped by task
+// its rows are the days the task was worked on instead.
+type TimeReportRow struct {
+	Label    string
+	TaskID   int
+	Duration time.Duration
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Ranges over which the due date and age of a task count towards its urgency.
+const (
+	urgencyDueWindow = 21 * 24 * time.Hour  // From two weeks before the due date to a week after it
+	urgencyDueLead   = 14 * 24 * time.Hour  // How long before its due date a task starts becoming urgent
+	urgencyMaxAge    = 365 * 24 * time.Hour // Tasks older than this count as old as they get
+)
+
+// UrgencyCoefficients weigh the properties of a task that make up its urgency score. Each property is
+// scaled to between 0 and 1 and multiplied by its coefficient, so the coefficients set how much each
+// property can add. Negative coefficients make a task less urgent.
+type UrgencyCoefficients struct {
+	Priority float64            `yaml:"priority"` // Times the height of the priority on its scale
+	Due      float64            `yaml:"due"`      // Rises from 0.2 two weeks before the due date to 1 a week after it
+	Age      float64            `yaml:"age"`      // Rises from 0 when created to 1 after a year
+	Blocked  float64            `yaml:"blocked"`  // When the task waits on open dependencies
+	Active   float64            `yaml:"active"`   // When the task's status is in the active category
+	Tags     float64            `yaml:"tags"`     // 0.8 for one tag, 0.9 for two and 1 for more
+	Tag      map[string]float64 `yaml:"tag"`      // Added for each of these tags the task has
+}
+
+// DefaultUrgencyCoefficients returns the coefficients used when none are configured.
+func DefaultUrgencyCoefficients() UrgencyCoefficients {
+	return UrgencyCoefficients{
+		Priority: 6,
+		Due:      12,
+		Age:      2,
+		Blocked:  -5,
+		Active:   4,
+		Tags:     1,
+	}
+}
+
+// Score computes how urgent a task is at the given moment. Higher scores are more urgent. Done tasks
+// score zero.
+func (c UrgencyCoefficients) Score(task *Task, priorities PriorityScale, now time.Time) float64 {
+	if task.IsDone() {
+		return 0
+	}
+
+	var score float64
+	if priorities.Level(task.Priority) != nil {
+		score += c.Priority * float64(task.Priority) / float64(len(priorities.Levels))
+	}
+	if task.DueAt != nil {
+		score += c.Due * dueUrgency(task.DueAt.Sub(now))
+	}
+	if !task.CreatedAt.IsZero() {
+		score += c.Age * math.Min(math.Max(float64(now.Sub(task.CreatedAt))/float64(urgencyMaxAge), 0), 1)
+	}
+	if task.IsBlocked() {
+		score += c.Blocked
+	}
+	if task.StatusCategory == CategoryActive {
+		score += c.Active
+	}
+	switch len(task.Tags) {
+	case 0:
+	case 1:
+		score += c.Tags * 0.8
+	case 2:
+		score += c.Tags * 0.9
+	default:
+		score += c.Tags
+	}
+	for _, tag := range task.Tags {
+		score += c.Tag[tag]
+	}
+
+	return math.Round(score*100) / 100
+}
+
+// dueUrgency scales the time left until a due date to between 0.2 for two weeks or more and 1 for a
+// week or more overdue.
+func dueUrgency(left time.Duration) float64 {
+	switch {
+	case left >= urgencyDueLead:
+		return 0.2
+	case left <= urgencyDueLead-urgencyDueWindow:
+		return 1
+	default:
+		return 0.2 + 0.8*float64(urgencyDueLead-left)/float64(urgencyDueWindow)
+	}
+}
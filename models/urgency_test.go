@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUrgencyCoefficientsScore(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	days := func(n float64) time.Time {
+		return now.Add(time.Duration(n * float64(24*time.Hour)))
+	}
+	ptr := func(t time.Time) *time.Time {
+		return &t
+	}
+
+	coefficients := DefaultUrgencyCoefficients()
+	coefficients.Tag = map[string]float64{"urgent": 5}
+
+	tests := []struct {
+		name string
+		task Task
+		want float64
+	}{
+		{name: "lowest priority", task: Task{Priority: 1}, want: 2},
+		{name: "highest priority", task: Task{Priority: 3}, want: 6},
+		{name: "priority outside the scale", task: Task{Priority: 7}, want: 0},
+		{name: "due in a month", task: Task{Priority: 1, DueAt: ptr(days(30))}, want: 4.4},
+		{name: "due now", task: Task{Priority: 1, DueAt: ptr(now)}, want: 10.8},
+		{name: "a week overdue", task: Task{Priority: 1, DueAt: ptr(days(-7))}, want: 14},
+		{name: "half a year old", task: Task{Priority: 1, CreatedAt: days(-182.5)}, want: 3},
+		{name: "older than a year", task: Task{Priority: 1, CreatedAt: days(-800)}, want: 4},
+		{name: "blocked", task: Task{Priority: 1, BlockedBy: []int{2}}, want: -3},
+		{name: "active", task: Task{Priority: 1, StatusCategory: CategoryActive}, want: 6},
+		{name: "one tag", task: Task{Priority: 1, Tags: []string{"work"}}, want: 2.8},
+		{name: "two tags", task: Task{Priority: 1, Tags: []string{"work", "home"}}, want: 2.9},
+		{name: "three tags", task: Task{Priority: 1, Tags: []string{"work", "home", "garden"}}, want: 3},
+		{name: "weighted tag", task: Task{Priority: 1, Tags: []string{"urgent"}}, want: 7.8},
+		{name: "done", task: Task{Priority: 3, DueAt: ptr(days(-7)), StatusCategory: CategoryDone}, want: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := coefficients.Score(&test.task, DefaultPriorityScale(), now); got != test.want {
+				t.Errorf("Score = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
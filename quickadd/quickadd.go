@@ -0,0 +1,136 @@
+package quickadd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrUnclosedQuote = errors.New("quoted value is missing its closing quote")
+	ErrRepeated      = errors.New("property is given more than once")
+)
+
+// Entry is a task written in the quick-add syntax, split into its title and properties. The properties
+// are returned as written; it is up to the caller to resolve them, e.g. the priority by name.
+type Entry struct {
+	Title    string
+	Tags     []string // From +tag words
+	Priority string   // From a !priority word
+	Status   string   // From an @status word
+	Due      string   // From due:value
+	Project  string   // From project:value
+	Estimate string   // From estimate:value
+	Repeat   string   // From repeat:value
+	Parent   string   // From parent:value
+}
+
+// Parse reads the quick-add syntax, in which the properties of a task are written among the words of its
+// title: "Fix login bug +backend +urgent !high @in-progress due:fri". The keys due, project, estimate,
+// repeat and parent are recognized; a word with any other key stays in the title, so titles such as
+// "Re: budget" are left alone. Values containing spaces are quoted, as in due:"next fri", and a word
+// starting with a backslash is kept in the title without the backslash, e.g. \+1. The title keeps the
+// spacing it was written with, less the property words.
+func Parse(input string) (Entry, error) {
+	var entry Entry
+	var title strings.Builder
+
+	words, end := split(input), 0
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		space := input[end:word.start]
+		end = word.end
+
+		if escaped, ok := strings.CutPrefix(word.text, `\`); ok && escaped != "" {
+			title.WriteString(space + escaped)
+			continue
+		}
+		if tag, ok := strings.CutPrefix(word.text, "+"); ok && tag != "" {
+			entry.Tags = append(entry.Tags, tag)
+			continue
+		}
+
+		field, value := entry.field(word.text)
+		if field == nil {
+			title.WriteString(space + word.text)
+			continue
+		}
+		if strings.HasPrefix(value, `"`) {
+			var err error
+			if value, i, err = unquote(input, words, i, word.end-len(value)); err != nil {
+				return Entry{}, fmt.Errorf("%w: %s", err, word.text)
+			}
+			end = words[i].end
+		}
+		if *field != "" {
+			return Entry{}, fmt.Errorf("%w: %s", ErrRepeated, word.text)
+		}
+		*field = value
+	}
+
+	entry.Title = strings.TrimSpace(title.String())
+	return entry, nil
+}
+
+// word is a run of non-space characters of the input, with its position.
+type word struct {
+	text       string
+	start, end int
+}
+
+// split breaks the input into its words, remembering where each one is.
+func split(input string) []word {
+	var words []word
+	start := -1
+	for i, r := range input {
+		switch {
+		case unicode.IsSpace(r) && start >= 0:
+			words = append(words, word{text: input[start:i], start: start, end: i})
+			start = -1
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, word{text: input[start:], start: start, end: len(input)})
+	}
+	return words
+}
+
+// field finds the property a word sets and its value, returning nil when the word is part of the title.
+func (e *Entry) field(word string) (*string, string) {
+	if len(word) > 1 {
+		switch word[0] {
+		case '!':
+			return &e.Priority, word[1:]
+		case '@':
+			return &e.Status, word[1:]
+		}
+	}
+
+	key, value, ok := strings.Cut(word, ":")
+	if !ok || value == "" {
+		return nil, ""
+	}
+	fields := map[string]*string{
+		"due":      &e.Due,
+		"project":  &e.Project,
+		"estimate": &e.Estimate,
+		"repeat":   &e.Repeat,
+		"parent":   &e.Parent,
+	}
+	return fields[strings.ToLower(key)], value
+}
+
+// unquote reads a quoted value whose opening quote is at position start of the input, within words[i]. The
+// value may run over the following words and keeps the spacing between them; it is returned with the index
+// of the word that closes it.
+func unquote(input string, words []word, i, start int) (string, int, error) {
+	for j := i; j < len(words); j++ {
+		if strings.HasSuffix(words[j].text, `"`) && words[j].end-1 > start {
+			return input[start+1 : words[j].end-1], j, nil
+		}
+	}
+	return "", len(words), ErrUnclosedQuote
+}
@@ -0,0 +1,136 @@
+package quickadd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Entry
+	}{
+		{
+			name:  "plain title",
+			input: "Buy milk",
+			want:  Entry{Title: "Buy milk"},
+		},
+		{
+			name:  "every property",
+			input: "Fix login bug +backend +urgent !high @in-progress due:fri project:web estimate:2h repeat:weekly parent:12",
+			want: Entry{
+				Title:    "Fix login bug",
+				Tags:     []string{"backend", "urgent"},
+				Priority: "high",
+				Status:   "in-progress",
+				Due:      "fri",
+				Project:  "web",
+				Estimate: "2h",
+				Repeat:   "weekly",
+				Parent:   "12",
+			},
+		},
+		{
+			name:  "properties between title words",
+			input: "+docs Write !low the notes",
+			want:  Entry{Title: "Write the notes", Tags: []string{"docs"}, Priority: "low"},
+		},
+		{
+			name:  "quoted value",
+			input: `Call the bank due:"next fri 10:00" +home`,
+			want:  Entry{Title: "Call the bank", Due: "next fri 10:00", Tags: []string{"home"}},
+		},
+		{
+			name:  "quoted value keeps its spacing",
+			input: `Plan project:"Big   Move"`,
+			want:  Entry{Title: "Plan", Project: "Big   Move"},
+		},
+		{
+			name:  "quoted single word",
+			input: `Ship due:"fri"`,
+			want:  Entry{Title: "Ship", Due: "fri"},
+		},
+		{
+			name:  "title keeps its spacing",
+			input: "Fix   login +backend  bug",
+			want:  Entry{Title: "Fix   login  bug", Tags: []string{"backend"}},
+		},
+		{
+			name:  "escaped words",
+			input: `\+1 for \!important \due:tomorrow`,
+			want:  Entry{Title: "+1 for !important due:tomorrow"},
+		},
+		{
+			name:  "lone backslash",
+			input: `A \ B`,
+			want:  Entry{Title: `A \ B`},
+		},
+		{
+			name:  "unknown key",
+			input: "Re: budget",
+			want:  Entry{Title: "Re: budget"},
+		},
+		{
+			name:  "unknown keys with values",
+			input: "Read https://example.com at 10:30",
+			want:  Entry{Title: "Read https://example.com at 10:30"},
+		},
+		{
+			name:  "key without value",
+			input: "Decide the due: date",
+			want:  Entry{Title: "Decide the due: date"},
+		},
+		{
+			name:  "keys are case-insensitive",
+			input: "Pay rent DUE:eom",
+			want:  Entry{Title: "Pay rent", Due: "eom"},
+		},
+		{
+			name:  "bare symbols",
+			input: "A + B ! @",
+			want:  Entry{Title: "A + B ! @"},
+		},
+		{
+			name:  "no title",
+			input: "+inbox !high",
+			want:  Entry{Tags: []string{"inbox"}, Priority: "high"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse(test.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", test.input, err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", test.input, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{name: "unclosed quote", input: `Call due:"next fri`, want: ErrUnclosedQuote},
+		{name: "lone quote", input: `Call due:"`, want: ErrUnclosedQuote},
+		{name: "repeated priority", input: "Fix !high !low", want: ErrRepeated},
+		{name: "repeated status", input: "Fix @pending @done", want: ErrRepeated},
+		{name: "repeated key", input: "Fix due:fri due:mon", want: ErrRepeated},
+		{name: "repeated quoted key", input: `Fix due:fri due:"next mon"`, want: ErrRepeated},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Parse(test.input); !errors.Is(err, test.want) {
+				t.Errorf("Parse(%q) returned error %v, want %v", test.input, err, test.want)
+			}
+		})
+	}
+}
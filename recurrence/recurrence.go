@@ -0,0 +1,317 @@
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRule = errors.New("unrecognized repeat rule, try e.g. daily, weekly, every 3 days, mon,wed,fri or FREQ=WEEKLY;BYDAY=MO")
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+const untilLayout = "20060102T150405Z"
+
+var dayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var dayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var unitFrequencies = map[string]Frequency{
+	"day": Daily, "days": Daily,
+	"week": Weekly, "weeks": Weekly,
+	"month": Monthly, "months": Monthly,
+	"year": Yearly, "years": Yearly,
+}
+
+var everyN = regexp.MustCompile(`^every\s+(\d+)\s+(\w+)$`)
+
+// Rule is the subset of an RFC 5545 RRULE used to repeat tasks.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []time.Weekday
+	Count    int        // Occurrences left in the series including the current one, 0 for unlimited
+	Until    *time.Time // No occurrence is generated after this moment
+}
+
+// Parse reads either a friendly rule ("daily", "every 2 weeks", "mon,wed,fri", "weekdays")
+// or an RRULE string ("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=5").
+func Parse(input string) (*Rule, error) {
+	value := strings.TrimSpace(input)
+	if value == "" {
+		return nil, ErrInvalidRule
+	}
+
+	if strings.Contains(strings.ToUpper(value), "FREQ=") {
+		return parseRRule(value)
+	}
+
+	value = strings.ToLower(strings.Join(strings.Fields(value), " "))
+	switch value {
+	case "daily", "every day":
+		return &Rule{Freq: Daily, Interval: 1}, nil
+	case "weekly", "every week":
+		return &Rule{Freq: Weekly, Interval: 1}, nil
+	case "monthly", "every month":
+		return &Rule{Freq: Monthly, Interval: 1}, nil
+	case "yearly", "annually", "every year":
+		return &Rule{Freq: Yearly, Interval: 1}, nil
+	case "weekdays", "every weekday":
+		return &Rule{Freq: Weekly, Interval: 1, ByDay: []time.Weekday{
+			time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+		}}, nil
+	}
+
+	if matches := everyN.FindStringSubmatch(value); matches != nil {
+		interval, _ := strconv.Atoi(matches[1])
+		freq, ok := unitFrequencies[matches[2]]
+		if !ok || interval <= 0 {
+			return nil, ErrInvalidRule
+		}
+		return &Rule{Freq: freq, Interval: interval}, nil
+	}
+
+	var days []time.Weekday
+	for _, name := range strings.FieldsFunc(strings.TrimPrefix(value, "every "), func(r rune) bool {
+		return r == ',' || r == ' '
+	}) {
+		day, ok := dayNames[name]
+		if !ok {
+			return nil, ErrInvalidRule
+		}
+		days = append(days, day)
+	}
+	if len(days) == 0 {
+		return nil, ErrInvalidRule
+	}
+
+	return &Rule{Freq: Weekly, Interval: 1, ByDay: days}, nil
+}
+
+func parseRRule(value string) (*Rule, error) {
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(strings.TrimPrefix(strings.ToUpper(value), "RRULE:"), ";") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, ErrInvalidRule
+		}
+
+		switch key {
+		case "FREQ":
+			rule.Freq = Frequency(val)
+			if rule.Freq != Daily && rule.Freq != Weekly && rule.Freq != Monthly && rule.Freq != Yearly {
+				return nil, fmt.Errorf("%w: unsupported frequency %s", ErrInvalidRule, val)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval <= 0 {
+				return nil, ErrInvalidRule
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count <= 0 {
+				return nil, ErrInvalidRule
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := time.Parse(untilLayout, val)
+			if err != nil {
+				if until, err = time.ParseInLocation("20060102", val, time.Local); err != nil {
+					return nil, ErrInvalidRule
+				}
+				until = until.Add(24*time.Hour - time.Second)
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, ok := dayCodes[code]
+				if !ok {
+					return nil, fmt.Errorf("%w: unsupported BYDAY value %s", ErrInvalidRule, code)
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "WKST":
+		default:
+			return nil, fmt.Errorf("%w: unsupported RRULE part %s", ErrInvalidRule, key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, ErrInvalidRule
+	}
+	if len(rule.ByDay) > 0 && rule.Freq != Weekly {
+		return nil, fmt.Errorf("%w: BYDAY is only supported with FREQ=WEEKLY", ErrInvalidRule)
+	}
+
+	return rule, nil
+}
+
+// String returns the rule in RRULE form, which is how it is stored.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		parts = append(parts, "BYDAY="+strings.Join(r.dayCodes(), ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Describe returns a human-readable summary such as "every 2 weeks on Mon, Fri".
+func (r *Rule) Describe() string {
+	units := map[Frequency]string{Daily: "day", Weekly: "week", Monthly: "month", Yearly: "year"}
+
+	description := "every " + units[r.Freq]
+	if r.Interval > 1 {
+		description = fmt.Sprintf("every %d %ss", r.Interval, units[r.Freq])
+	}
+	if len(r.ByDay) > 0 {
+		names := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			names[i] = day.String()[:3]
+		}
+		description += " on " + strings.Join(names, ", ")
+	}
+	if r.Count > 0 {
+		description += fmt.Sprintf(", %d left", r.Count)
+	}
+	if r.Until != nil {
+		description += ", until " + r.Until.Local().Format("2006-01-02")
+	}
+	return description
+}
+
+// Next returns the first occurrence strictly after from, or false when the series has ended.
+func (r *Rule) Next(from time.Time) (time.Time, bool) {
+	next, _, ok := r.NextAfter(from, from)
+	return next, ok
+}
+
+// NextAfter returns the first occurrence of the series anchored at start that falls after the given
+// moment, along with the rule for the rest of the series from that occurrence. Occurrences missed in
+// between are skipped but still count towards COUNT. It returns false when the series has ended.
+func (r *Rule) NextAfter(start, after time.Time) (time.Time, *Rule, bool) {
+	rule, next := r, start
+	for n := 1; ; n++ {
+		if rule.Count == 1 {
+			return time.Time{}, nil, false
+		}
+		next = r.occurrence(start, next, n)
+		if r.Until != nil && next.After(*r.Until) {
+			return time.Time{}, nil, false
+		}
+		rule = rule.Advance()
+		if next.After(after) {
+			return next, rule, true
+		}
+	}
+}
+
+// occurrence returns the nth occurrence after start, given the previous one.
+func (r *Rule) occurrence(start, previous time.Time, n int) time.Time {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	switch {
+	case r.Freq == Weekly && len(r.ByDay) > 0:
+		return r.nextByDay(previous, interval)
+	case r.Freq == Daily:
+		return start.AddDate(0, 0, n*interval)
+	case r.Freq == Weekly:
+		return start.AddDate(0, 0, 7*n*interval)
+	case r.Freq == Monthly:
+		return addMonths(start, n*interval)
+	default:
+		return addMonths(start, 12*n*interval)
+	}
+}
+
+// Advance returns the rule for the next occurrence in the series, consuming one from Count.
+func (r *Rule) Advance() *Rule {
+	next := *r
+	if next.Count > 0 {
+		next.Count--
+	}
+	return &next
+}
+
+func (r *Rule) nextByDay(from time.Time, interval int) time.Time {
+	days := make(map[time.Weekday]bool)
+	for _, day := range r.ByDay {
+		days[day] = true
+	}
+
+	start := weekStart(from)
+	for i := 1; i <= 7*(interval+1); i++ {
+		candidate := from.AddDate(0, 0, i)
+		weeks := int(weekStart(candidate).Sub(start).Hours()/24+0.5) / 7
+		if days[candidate.Weekday()] && weeks%interval == 0 {
+			return candidate
+		}
+	}
+
+	return from.AddDate(0, 0, 7*interval)
+}
+
+func (r *Rule) dayCodes() []string {
+	codes := make([]string, len(r.ByDay))
+	for i, day := range r.ByDay {
+		codes[i] = strings.ToUpper(day.String()[:2])
+	}
+	return codes
+}
+
+// weekStart returns midnight on the Monday of t's week.
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// addMonths adds months to t, clamping the day to the end of the target month.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
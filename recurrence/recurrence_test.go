@@ -0,0 +1,138 @@
+package recurrence
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "daily", want: "FREQ=DAILY"},
+		{input: "Every Week", want: "FREQ=WEEKLY"},
+		{input: "annually", want: "FREQ=YEARLY"},
+		{input: "every 3 days", want: "FREQ=DAILY;INTERVAL=3"},
+		{input: "every 2 months", want: "FREQ=MONTHLY;INTERVAL=2"},
+		{input: "weekdays", want: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+		{input: "mon,wed,fri", want: "FREQ=WEEKLY;BYDAY=MO,WE,FR"},
+		{input: "every tuesday thursday", want: "FREQ=WEEKLY;BYDAY=TU,TH"},
+		{input: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=5", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=5"},
+		{input: "RRULE:freq=daily;interval=1;wkst=mo", want: "FREQ=DAILY"},
+		{input: "FREQ=MONTHLY;UNTIL=20261231T235959Z", want: "FREQ=MONTHLY;UNTIL=20261231T235959Z"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			rule, err := Parse(test.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", test.input, err)
+			}
+			if got := rule.String(); got != test.want {
+				t.Errorf("Parse(%q).String() = %q, want %q", test.input, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"sometimes",
+		"every 0 days",
+		"every 2 fortnights",
+		"mon,funday",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=DAILY;UNTIL=tomorrow",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYDAY=MO",
+		"FREQ=DAILY;BYMONTH=1",
+		"INTERVAL=2;FREQ",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			if _, err := Parse(input); !errors.Is(err, ErrInvalidRule) {
+				t.Errorf("Parse(%q) returned error %v, want %v", input, err, ErrInvalidRule)
+			}
+		})
+	}
+}
+
+func TestNextAfter(t *testing.T) {
+	// A Wednesday morning.
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	day := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 9, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		rule  string
+		after time.Time
+		want  time.Time
+		rest  string // The rule for the rest of the series, empty when it has ended
+	}{
+		{name: "daily", rule: "daily", after: start, want: day(10, 15), rest: "FREQ=DAILY"},
+		{name: "skips missed occurrences", rule: "daily", after: day(10, 17).Add(time.Hour), want: day(10, 18), rest: "FREQ=DAILY"},
+		{name: "interval", rule: "every 2 weeks", after: start, want: day(10, 28), rest: "FREQ=WEEKLY;INTERVAL=2"},
+		{name: "by day", rule: "mon,fri", after: start, want: day(10, 16), rest: "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{name: "by day with interval", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", after: start, want: day(10, 26), rest: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO"},
+		{name: "monthly clamps the day", rule: "monthly", after: start, want: day(11, 14), rest: "FREQ=MONTHLY"},
+		{name: "count", rule: "FREQ=DAILY;COUNT=3", after: start, want: day(10, 15), rest: "FREQ=DAILY;COUNT=2"},
+		{name: "count consumed by missed occurrences", rule: "FREQ=DAILY;COUNT=3", after: day(10, 15).Add(time.Hour), want: day(10, 16), rest: "FREQ=DAILY;COUNT=1"},
+		{name: "count ended by missed occurrences", rule: "FREQ=DAILY;COUNT=3", after: day(10, 16).Add(time.Hour)},
+		{name: "last of the count", rule: "FREQ=DAILY;COUNT=1", after: start},
+		{name: "before until", rule: "FREQ=DAILY;UNTIL=20261016T090000Z", after: day(10, 15), want: day(10, 16), rest: "FREQ=DAILY;UNTIL=20261016T090000Z"},
+		{name: "after until", rule: "FREQ=DAILY;UNTIL=20261016T085959Z", after: day(10, 15)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule, err := Parse(test.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", test.rule, err)
+			}
+
+			got, rest, ok := rule.NextAfter(start, test.after)
+			if test.rest == "" {
+				if ok {
+					t.Errorf("NextAfter() = %v, %q, want the series to have ended", got, rest.String())
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("NextAfter() ended the series, want %v", test.want)
+			}
+			if !got.Equal(test.want) || rest.String() != test.rest {
+				t.Errorf("NextAfter() = %v, %q, want %v, %q", got, rest.String(), test.want, test.rest)
+			}
+		})
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	rule := &Rule{Freq: Daily, Interval: 1, Count: 3}
+	if got := rule.Advance(); got.Count != 2 || rule.Count != 3 {
+		t.Errorf("Advance() left Count %d and the rule with %d, want 2 and 3", got.Count, rule.Count)
+	}
+
+	unlimited := &Rule{Freq: Daily, Interval: 1}
+	if got := unlimited.Advance(); got.Count != 0 {
+		t.Errorf("Advance() of an unlimited rule left Count %d, want 0", got.Count)
+	}
+}
+
+func TestMonthlyEndOfMonth(t *testing.T) {
+	rule := &Rule{Freq: Monthly, Interval: 1}
+	start := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+
+	got, ok := rule.Next(start)
+	if want := time.Date(2026, 2, 28, 9, 0, 0, 0, time.UTC); !ok || !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, %v, want %v", start, got, ok, want)
+	}
+}
@@ -0,0 +1,301 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kkumar-gcc/todo/models"
+)
+
+var (
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrNothingToRedo = errors.New("nothing to redo")
+	ErrTrackedTime   = errors.New("time was tracked on a task the operation would delete")
+)
+
+type operationKey struct{}
+
+// WithOperation returns a context under which every task change is journaled as part of the operation.
+func WithOperation(ctx context.Context, id int) context.Context {
+	return context.WithValue(ctx, operationKey{}, id)
+}
+
+var operationColumns = `id, description, created_at, undone_at, redoable,
+    (SELECT COUNT(*) FROM task_events WHERE task_events.operation_id = operations.id)`
+
+// OperationRepository defines the methods that the Operation repository should implement.
+type OperationRepository interface {
+	Begin(ctx context.Context, description string) (int, error)
+	End(ctx context.Context, id int) error
+	GetRecent(ctx context.Context, limit int) ([]models.Operation, error)
+	Undo(ctx context.Context) (*models.Operation, error)
+	Redo(ctx context.Context) (*models.Operation, error)
+}
+
+type OperationRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewOperationRepository(db *sql.DB) OperationRepository {
+	return &OperationRepositoryImpl{
+		db: db,
+	}
+}
+
+func scanOperation(row scanner) (*models.Operation, error) {
+	var operation models.Operation
+	err := row.Scan(&operation.ID, &operation.Description, &operation.CreatedAt, &operation.UndoneAt, &operation.Redoable, &operation.EventCount)
+	if err != nil {
+		return nil, err
+	}
+	return &operation, nil
+}
+
+// Begin starts a new operation, whose ID is then passed to WithOperation.
+func (r *OperationRepositoryImpl) Begin(ctx context.Context, description string) (int, error) {
+	now := time.Now()
+	result, err := r.db.ExecContext(ctx, "INSERT INTO operations (description, created_at) VALUES (?, ?)", description, utc(&now))
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	return int(id), err
+}
+
+// End finishes an operation. An operation that changed nothing is forgotten, while one that did
+// discards the undone operations from the redo history.
+func (r *OperationRepositoryImpl) End(ctx context.Context, id int) error {
+	return transaction(ctx, r.db, func(tx *sql.Tx) error {
+		var changed bool
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM task_events WHERE operation_id = ?)", id).Scan(&changed); err != nil {
+			return err
+		}
+
+		if !changed {
+			_, err := tx.ExecContext(ctx, "DELETE FROM operations WHERE id = ?", id)
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, "UPDATE operations SET redoable = 0 WHERE redoable = 1 AND id != ?", id)
+		return err
+	})
+}
+
+func (r *OperationRepositoryImpl) GetRecent(ctx context.Context, limit int) ([]models.Operation, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+operationColumns+" FROM operations ORDER BY id DESC LIMIT ?", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var operations []models.Operation
+	for rows.Next() {
+		operation, err := scanOperation(rows)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, *operation)
+	}
+
+	return operations, rows.Err()
+}
+
+// Undo reverts the most recent operation that has not been undone, restoring every task it changed
+// to its earlier state, including deleted tasks with their original IDs and timestamps.
+func (r *OperationRepositoryImpl) Undo(ctx context.Context) (*models.Operation, error) {
+	query := "SELECT " + operationColumns + " FROM operations WHERE undone_at IS NULL ORDER BY id DESC LIMIT 1"
+	return r.replay(ctx, query, ErrNothingToUndo, true)
+}
+
+// Redo reapplies the earliest operation undone since the last new operation.
+func (r *OperationRepositoryImpl) Redo(ctx context.Context) (*models.Operation, error) {
+	query := "SELECT " + operationColumns + " FROM operations WHERE redoable = 1 ORDER BY id LIMIT 1"
+	return r.replay(ctx, query, ErrNothingToRedo, false)
+}
+
+// replay applies the snapshots of the operation selected by query: the earlier states in reverse order
+// to undo it, or the later states in order to redo it.
+func (r *OperationRepositoryImpl) replay(ctx context.Context, query string, errNone error, undo bool) (*models.Operation, error) {
+	var operation *models.Operation
+	err := transaction(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		operation, err = scanOperation(tx.QueryRowContext(ctx, query))
+		if errors.Is(err, sql.ErrNoRows) {
+			return errNone
+		}
+		if err != nil {
+			return err
+		}
+
+		// Restored tasks may reference each other, so check foreign keys once everything is in place.
+		if _, err := tx.ExecContext(ctx, "PRAGMA defer_foreign_keys = ON"); err != nil {
+			return err
+		}
+
+		order := "ASC"
+		if undo {
+			order = "DESC"
+		}
+		rows, err := tx.QueryContext(ctx, "SELECT task_id, snapshot_before, snapshot_after FROM task_events WHERE operation_id = ? ORDER BY id "+order, operation.ID)
+		if err != nil {
+			return err
+		}
+
+		type step struct {
+			taskID int
+			target *taskSnapshot
+			other  *taskSnapshot // The state on the other side of the operation
+		}
+		var steps []step
+		for rows.Next() {
+			var taskID int
+			var before, after sql.NullString
+			if err := rows.Scan(&taskID, &before, &after); err != nil {
+				rows.Close()
+				return err
+			}
+			target, other := after, before
+			if undo {
+				target, other = before, after
+			}
+			targetSnapshot, err := decodeSnapshot(target)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			otherSnapshot, err := decodeSnapshot(other)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			steps = append(steps, step{taskID: taskID, target: targetSnapshot, other: otherSnapshot})
+		}
+		rows.Close()
+
+		for _, step := range steps {
+			if step.target == nil {
+				if err := checkTimeData(ctx, tx, step.taskID, step.other); err != nil {
+					return err
+				}
+			}
+			if err := restoreSnapshot(ctx, tx, step.taskID, step.target); err != nil {
+				return err
+			}
+		}
+
+		if undo {
+			now := time.Now()
+			_, err = tx.ExecContext(ctx, "UPDATE operations SET undone_at = ?, redoable = 1 WHERE id = ?", utc(&now), operation.ID)
+		} else {
+			_, err = tx.ExecContext(ctx, "UPDATE operations SET undone_at = NULL, redoable = 0 WHERE id = ?", operation.ID)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+// checkTimeData refuses to delete a task that has time entries or pomodoros other does not keep, such as
+// time tracked on a task after it was created, as they are deleted with the task and could not be restored.
+func checkTimeData(ctx context.Context, tx *sql.Tx, id int, other *taskSnapshot) error {
+	var entries, pomodoros []int
+	if other != nil {
+		for _, entry := range other.TimeEntries {
+			entries = append(entries, entry.ID)
+		}
+		for _, pomodoro := range other.Pomodoros {
+			pomodoros = append(pomodoros, pomodoro.ID)
+		}
+	}
+
+	for table, kept := range map[string][]int{"time_entries": entries, "pomodoros": pomodoros} {
+		ids, err := queryIDs(ctx, tx, "SELECT id FROM "+table+" WHERE task_id = ?", id)
+		if err != nil {
+			return err
+		}
+		for _, recordID := range ids {
+			if !slices.Contains(kept, recordID) {
+				return fmt.Errorf("%w (task %d)", ErrTrackedTime, id)
+			}
+		}
+	}
+	return nil
+}
+
+// restoreSnapshot puts a task back into the given state, deleting it when target is nil and
+// re-creating it with its original ID, and any time tracked on it, when it no longer exists. The
+// change is recorded in the audit trail outside of any operation.
+func restoreSnapshot(ctx context.Context, tx *sql.Tx, id int, target *taskSnapshot) error {
+	current, err := loadSnapshot(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if target == nil {
+		if current == nil {
+			return nil
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM tasks WHERE id = ?", id); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, current, nil)
+	}
+
+	// Match the format of CURRENT_TIMESTAMP, which set the original value.
+	createdAt := target.CreatedAt.UTC().Format(time.DateTime)
+	if current == nil {
+		query := `INSERT INTO tasks (id, title, notes, status, status_reason, created_at, completed_at, priority, due_at, recurrence, parent_id, project_id, archived_at, deleted_at,
+                  estimate_seconds, estimate_points, wait_until, resurfaced_at)
+                  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		_, err = tx.ExecContext(ctx, query, id, target.Title, target.Notes, target.Status, target.StatusReason, createdAt, utc(target.CompletedAt), target.Priority,
+			utc(target.DueAt), target.Recurrence, target.ParentID, target.ProjectID, utc(target.ArchivedAt), utc(target.DeletedAt),
+			seconds(target.Estimate.Duration), target.Estimate.Points, utc(target.WaitUntil), utc(target.ResurfacedAt))
+	} else {
+		query := `UPDATE tasks SET title = ?, notes = ?, status = ?, status_reason = ?, created_at = ?, completed_at = ?, priority = ?, due_at = ?, recurrence = ?, parent_id = ?, project_id = ?,
+                  archived_at = ?, deleted_at = ?, estimate_seconds = ?, estimate_points = ?, wait_until = ?, resurfaced_at = ? WHERE id = ?`
+		_, err = tx.ExecContext(ctx, query, target.Title, target.Notes, target.Status, target.StatusReason, createdAt, utc(target.CompletedAt), target.Priority,
+			utc(target.DueAt), target.Recurrence, target.ParentID, target.ProjectID, utc(target.ArchivedAt), utc(target.DeletedAt),
+			seconds(target.Estimate.Duration), target.Estimate.Points, utc(target.WaitUntil), utc(target.ResurfacedAt), id)
+	}
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		if err := restoreTimeData(ctx, tx, target); err != nil {
+			return err
+		}
+	}
+
+	if err := syncTags(ctx, tx, id, target.Tags); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_dependencies WHERE task_id = ? OR depends_on_id = ?", id, id); err != nil {
+		return err
+	}
+	query := "INSERT OR IGNORE INTO task_dependencies (task_id, depends_on_id) SELECT ?, ? WHERE EXISTS (SELECT 1 FROM tasks WHERE id = ?)"
+	for _, dependency := range target.DependsOn {
+		if _, err := tx.ExecContext(ctx, query, id, dependency, dependency); err != nil {
+			return err
+		}
+	}
+	for _, dependent := range target.Dependents {
+		if _, err := tx.ExecContext(ctx, query, dependent, id, dependent); err != nil {
+			return err
+		}
+	}
+
+	after, err := loadSnapshot(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	return recordEvent(ctx, tx, current, after)
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/kkumar-gcc/todo/models"
+)
+
+// PomodoroRepository defines the methods that the Pomodoro repository should implement.
+type PomodoroRepository interface {
+	Create(ctx context.Context, pomodoro *models.Pomodoro, note string) error
+	CountByTaskID(ctx context.Context, taskID int) (*models.PomodoroCount, error)
+}
+
+type PomodoroRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewPomodoroRepository(db *sql.DB) PomodoroRepository {
+	return &PomodoroRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create records a focus session together with a time entry covering it, described by note.
+func (r *PomodoroRepositoryImpl) Create(ctx context.Context, pomodoro *models.Pomodoro, note string) error {
+	return transaction(ctx, r.db, func(tx *sql.Tx) error {
+		query := "INSERT INTO time_entries (task_id, started_at, ended_at, note) VALUES (?, ?, ?, ?)"
+		result, err := tx.ExecContext(ctx, query, pomodoro.TaskID, utc(&pomodoro.StartedAt), utc(&pomodoro.EndedAt), note)
+		if err != nil {
+			return err
+		}
+		timeEntryID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		query = `INSERT INTO pomodoros (task_id, time_entry_id, started_at, ended_at, planned_seconds, completed)
+                 VALUES (?, ?, ?, ?, ?, ?)`
+		result, err = tx.ExecContext(ctx, query, pomodoro.TaskID, timeEntryID, utc(&pomodoro.StartedAt), utc(&pomodoro.EndedAt),
+			int64(pomodoro.Planned/time.Second), pomodoro.Completed)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		pomodoro.ID = int(id)
+
+		return nil
+	})
+}
+
+func (r *PomodoroRepositoryImpl) CountByTaskID(ctx context.Context, taskID int) (*models.PomodoroCount, error) {
+	query := "SELECT COALESCE(SUM(completed), 0), COALESCE(SUM(NOT completed), 0) FROM pomodoros WHERE task_id = ?"
+
+	var count models.PomodoroCount
+	if err := r.db.QueryRowContext(ctx, query, taskID).Scan(&count.Completed, &count.Interrupted); err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/kkumar-gcc/todo/models"
+)
+
+var (
+	ErrProjectNotFound = errors.New("project not found")
+)
+
+var projectColumns = `id, name, description, color, archived, created_at,
+    (SELECT COUNT(*) FROM tasks WHERE tasks.project_id = projects.id AND tasks.deleted_at IS NULL),
+    (SELECT COUNT(*) FROM tasks WHERE tasks.project_id = projects.id AND tasks.deleted_at IS NULL AND tasks.status IN ` + doneStatuses + `)`
+
+// ProjectRepository defines the methods that the Project repository should implement.
+type ProjectRepository interface {
+	Create(ctx context.Context, project *models.Project) error
+	GetAll(ctx context.Context, includeArchived bool) ([]models.Project, error)
+	GetByID(ctx context.Context, id int) (*models.Project, error)
+	GetByName(ctx context.Context, name string) (*models.Project, error)
+	Update(ctx context.Context, id int, updateFunc func(project *models.Project) (*models.Project, error)) error
+}
+
+type ProjectRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewProjectRepository(db *sql.DB) ProjectRepository {
+	return &ProjectRepositoryImpl{
+		db: db,
+	}
+}
+
+func scanProject(row scanner) (*models.Project, error) {
+	var project models.Project
+	err := row.Scan(&project.ID, &project.Name, &project.Description, &project.Color, &project.Archived, &project.CreatedAt,
+		&project.TaskCount, &project.CompletedCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (r *ProjectRepositoryImpl) Create(ctx context.Context, project *models.Project) error {
+	query := `INSERT INTO projects (name, description, color, archived)
+              VALUES (?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, project.Name, project.Description, project.Color, project.Archived)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	project.ID = int(id)
+
+	return nil
+}
+
+func (r *ProjectRepositoryImpl) GetAll(ctx context.Context, includeArchived bool) ([]models.Project, error) {
+	query := "SELECT " + projectColumns + " FROM projects"
+	if !includeArchived {
+		query += " WHERE archived = 0"
+	}
+	query += " ORDER BY name"
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []models.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, *project)
+	}
+
+	return projects, rows.Err()
+}
+
+func (r *ProjectRepositoryImpl) GetByID(ctx context.Context, id int) (*models.Project, error) {
+	query := "SELECT " + projectColumns + " FROM projects WHERE id = ?"
+	return scanProject(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *ProjectRepositoryImpl) GetByName(ctx context.Context, name string) (*models.Project, error) {
+	query := "SELECT " + projectColumns + " FROM projects WHERE name = ?"
+	return scanProject(r.db.QueryRowContext(ctx, query, name))
+}
+
+func (r *ProjectRepositoryImpl) Update(ctx context.Context, id int, updateFunc func(project *models.Project) (*models.Project, error)) error {
+	project, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	updatedProject, err := updateFunc(project)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE projects SET name = ?, description = ?, color = ?, archived = ? WHERE id = ?`
+	_, err = r.db.ExecContext(ctx, query, updatedProject.Name, updatedProject.Description, updatedProject.Color, updatedProject.Archived, id)
+	return err
+}
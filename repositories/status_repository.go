@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/kkumar-gcc/todo/models"
+)
+
+var (
+	ErrTransitionNotFound = errors.New("transition not found")
+)
+
+// doneStatuses selects the IDs of the statuses in the done category, for use in conditions on tasks.status.
+const doneStatuses = "(SELECT id FROM statuses WHERE category = '" + models.CategoryDone + "')"
+
+// StatusRepository defines the methods that the Status repository should implement.
+type StatusRepository interface {
+	Create(ctx context.Context, status *models.Status) error
+	GetAll(ctx context.Context) ([]models.Status, error)
+	AddTransition(ctx context.Context, from, to int) error
+	RemoveTransition(ctx context.Context, from, to int) error
+}
+
+type StatusRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewStatusRepository(db *sql.DB) StatusRepository {
+	return &StatusRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create adds a status along with its outgoing transitions.
+func (r *StatusRepositoryImpl) Create(ctx context.Context, status *models.Status) error {
+	return transaction(ctx, r.db, func(tx *sql.Tx) error {
+		query := `INSERT INTO statuses (name, label, color, category, requires_reason, position)
+                  VALUES (?, ?, ?, ?, ?, (SELECT COALESCE(MAX(position), 0) + 1 FROM statuses))`
+		result, err := tx.ExecContext(ctx, query, status.Name, status.Label, status.Color, status.Category, status.RequiresReason)
+		if err != nil {
+			return err
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		status.ID = int(id)
+
+		for _, to := range status.Transitions {
+			if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO status_transitions (from_status_id, to_status_id) VALUES (?, ?)", status.ID, to); err != nil {
+				return err
+			}
+		}
+
+		return tx.QueryRowContext(ctx, "SELECT position FROM statuses WHERE id = ?", status.ID).Scan(&status.Position)
+	})
+}
+
+// GetAll returns every status with its transitions, in workflow order.
+func (r *StatusRepositoryImpl) GetAll(ctx context.Context) ([]models.Status, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, label, color, category, requires_reason, position FROM statuses ORDER BY position, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statuses []models.Status
+	index := make(map[int]int)
+	for rows.Next() {
+		var status models.Status
+		if err := rows.Scan(&status.ID, &status.Name, &status.Label, &status.Color, &status.Category, &status.RequiresReason, &status.Position); err != nil {
+			return nil, err
+		}
+		index[status.ID] = len(statuses)
+		statuses = append(statuses, status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	transitions, err := r.db.QueryContext(ctx, `SELECT from_status_id, to_status_id FROM status_transitions
+        JOIN statuses ON statuses.id = status_transitions.to_status_id ORDER BY statuses.position, statuses.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer transitions.Close()
+
+	for transitions.Next() {
+		var from, to int
+		if err := transitions.Scan(&from, &to); err != nil {
+			return nil, err
+		}
+		if i, ok := index[from]; ok {
+			statuses[i].Transitions = append(statuses[i].Transitions, to)
+		}
+	}
+
+	return statuses, transitions.Err()
+}
+
+func (r *StatusRepositoryImpl) AddTransition(ctx context.Context, from, to int) error {
+	query := "INSERT OR IGNORE INTO status_transitions (from_status_id, to_status_id) VALUES (?, ?)"
+	_, err := r.db.ExecContext(ctx, query, from, to)
+	return err
+}
+
+func (r *StatusRepositoryImpl) RemoveTransition(ctx context.Context, from, to int) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM status_transitions WHERE from_status_id = ? AND to_status_id = ?", from, to)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return ErrTransitionNotFound
+	}
+	return nil
+}
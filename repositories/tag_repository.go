@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/kkumar-gcc/todo/models"
+)
+
+var (
+	ErrTagNotFound = errors.New("tag not found")
+)
+
+// TagRepository defines the methods that the Tag repository should implement.
+type TagRepository interface {
+	GetAll(ctx context.Context) ([]models.Tag, error)
+	GetByName(ctx context.Context, name string) (*models.Tag, error)
+	Rename(ctx context.Context, name, newName string) error
+	Merge(ctx context.Context, names []string, into string) error
+	Delete(ctx context.Context, name string) error
+}
+
+type TagRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewTagRepository(db *sql.DB) TagRepository {
+	return &TagRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *TagRepositoryImpl) GetAll(ctx context.Context) ([]models.Tag, error) {
+	query := `SELECT tags.id, tags.name, COUNT(tasks.id) FROM tags
+              LEFT JOIN task_tags ON task_tags.tag_id = tags.id
+              LEFT JOIN tasks ON tasks.id = task_tags.task_id AND tasks.deleted_at IS NULL
+              GROUP BY tags.id ORDER BY tags.name`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []models.Tag
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.TaskCount); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+func (r *TagRepositoryImpl) GetByName(ctx context.Context, name string) (*models.Tag, error) {
+	query := `SELECT tags.id, tags.name, (SELECT COUNT(*) FROM task_tags JOIN tasks ON tasks.id = task_tags.task_id WHERE task_tags.tag_id = tags.id AND tasks.deleted_at IS NULL)
+              FROM tags WHERE name = ?`
+
+	var tag models.Tag
+	err := r.db.QueryRowContext(ctx, query, name).Scan(&tag.ID, &tag.Name, &tag.TaskCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTagNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *TagRepositoryImpl) Rename(ctx context.Context, name, newName string) error {
+	return r.updateTagged(ctx, []string{name}, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "UPDATE tags SET name = ? WHERE name = ?", newName, name)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, _ := result.RowsAffected()
+		if rowsAffected == 0 {
+			return ErrTagNotFound
+		}
+
+		return nil
+	})
+}
+
+// Merge moves every task tagged with one of names onto the into tag, creating it if needed, and removes the merged tags.
+func (r *TagRepositoryImpl) Merge(ctx context.Context, names []string, into string) error {
+	return r.updateTagged(ctx, names, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO tags (name) VALUES (?)", into); err != nil {
+			return err
+		}
+
+		query := `INSERT OR IGNORE INTO task_tags (task_id, tag_id)
+                  SELECT task_tags.task_id, (SELECT id FROM tags WHERE name = ?) FROM task_tags
+                  JOIN tags ON tags.id = task_tags.tag_id WHERE tags.name IN (` + placeholders(len(names)) + `)`
+		if _, err := tx.ExecContext(ctx, query, append([]any{into}, stringArgs(names)...)...); err != nil {
+			return err
+		}
+
+		query = "DELETE FROM tags WHERE name != ? AND name IN (" + placeholders(len(names)) + ")"
+		_, err := tx.ExecContext(ctx, query, append([]any{into}, stringArgs(names)...)...)
+		return err
+	})
+}
+
+func (r *TagRepositoryImpl) Delete(ctx context.Context, name string) error {
+	return r.updateTagged(ctx, []string{name}, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "DELETE FROM tags WHERE name = ?", name)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, _ := result.RowsAffected()
+		if rowsAffected == 0 {
+			return ErrTagNotFound
+		}
+
+		return nil
+	})
+}
+
+// updateTagged runs fn, which changes the given tags, in a transaction and records an event for every
+// task carrying one of them, so the change shows in the history of those tasks and can be undone.
+func (r *TagRepositoryImpl) updateTagged(ctx context.Context, names []string, fn func(tx *sql.Tx) error) error {
+	return transaction(ctx, r.db, func(tx *sql.Tx) error {
+		query := `SELECT DISTINCT task_tags.task_id FROM task_tags JOIN tags ON tags.id = task_tags.tag_id
+                  WHERE tags.name IN (` + placeholders(len(names)) + `) ORDER BY task_tags.task_id`
+		ids, err := queryIDs(ctx, tx, query, stringArgs(names)...)
+		if err != nil {
+			return err
+		}
+
+		return updateSnapshots(ctx, tx, ids, func() error {
+			return fn(tx)
+		})
+	})
+}
@@ -0,0 +1,356 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/user"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/kkumar-gcc/todo/models"
+)
+
+// TaskEventRepository defines the methods that the TaskEvent repository should implement.
+// Events are written by TaskRepository in the same transaction as the change they record.
+type TaskEventRepository interface {
+	GetByTaskID(ctx context.Context, taskID int) ([]models.TaskEvent, error)
+	GetSince(ctx context.Context, since time.Time) ([]models.TaskEvent, error)
+}
+
+type TaskEventRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewTaskEventRepository(db *sql.DB) TaskEventRepository {
+	return &TaskEventRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *TaskEventRepositoryImpl) GetByTaskID(ctx context.Context, taskID int) ([]models.TaskEvent, error) {
+	query := "SELECT id, task_id, type, title, changes, actor, created_at FROM task_events WHERE task_id = ? ORDER BY created_at, id"
+	return r.query(ctx, query, taskID)
+}
+
+func (r *TaskEventRepositoryImpl) GetSince(ctx context.Context, since time.Time) ([]models.TaskEvent, error) {
+	query := "SELECT id, task_id, type, title, changes, actor, created_at FROM task_events WHERE created_at >= ? ORDER BY created_at, id"
+	return r.query(ctx, query, utc(&since))
+}
+
+func (r *TaskEventRepositoryImpl) query(ctx context.Context, query string, args ...any) ([]models.TaskEvent, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []models.TaskEvent
+	for rows.Next() {
+		var event models.TaskEvent
+		var changes string
+		if err := rows.Scan(&event.ID, &event.TaskID, &event.Type, &event.Title, &changes, &event.Actor, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(changes), &event.Changes); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// taskSnapshot is the complete stored state of a task, kept with each event so the change can be undone.
+type taskSnapshot struct {
+	ID           int             `json:"id"`
+	Title        string          `json:"title"`
+	Notes        string          `json:"notes"`
+	Status       int             `json:"status"`
+	StatusReason string          `json:"status_reason"`
+	CreatedAt    time.Time       `json:"created_at"`
+	CompletedAt  *time.Time      `json:"completed_at"`
+	Priority     int             `json:"priority"`
+	Tags         []string        `json:"tags"`
+	DueAt        *time.Time      `json:"due_at"`
+	Recurrence   string          `json:"recurrence"`
+	ParentID     *int            `json:"parent_id"`
+	ProjectID    *int            `json:"project_id"`
+	ArchivedAt   *time.Time      `json:"archived_at"`
+	DeletedAt    *time.Time      `json:"deleted_at"`
+	Estimate     models.Estimate `json:"estimate"`
+	WaitUntil    *time.Time      `json:"wait_until"`
+	ResurfacedAt *time.Time      `json:"resurfaced_at"`
+	DependsOn    []int           `json:"depends_on"` // IDs of the tasks this task depends on
+	Dependents   []int           `json:"dependents"` // IDs of the tasks depending on this task
+
+	// Time tracked on the task, only kept when the task is purged, as it is deleted together with the task.
+	TimeEntries []timeEntrySnapshot `json:"time_entries,omitempty"`
+	Pomodoros   []pomodoroSnapshot  `json:"pomodoros,omitempty"`
+}
+
+type timeEntrySnapshot struct {
+	ID        int        `json:"id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Note      string     `json:"note"`
+}
+
+type pomodoroSnapshot struct {
+	ID             int       `json:"id"`
+	TimeEntryID    *int      `json:"time_entry_id"`
+	StartedAt      time.Time `json:"started_at"`
+	EndedAt        time.Time `json:"ended_at"`
+	PlannedSeconds int       `json:"planned_seconds"`
+	Completed      bool      `json:"completed"`
+}
+
+func (s *taskSnapshot) task() *models.Task {
+	if s == nil {
+		return nil
+	}
+	return &models.Task{ID: s.ID, Title: s.Title, Notes: s.Notes, Status: s.Status, StatusReason: s.StatusReason, CreatedAt: s.CreatedAt, CompletedAt: s.CompletedAt,
+		Priority: s.Priority, Tags: s.Tags, DueAt: s.DueAt, Recurrence: s.Recurrence, ParentID: s.ParentID, ProjectID: s.ProjectID, ArchivedAt: s.ArchivedAt, DeletedAt: s.DeletedAt, Estimate: s.Estimate,
+		WaitUntil: s.WaitUntil, ResurfacedAt: s.ResurfacedAt}
+}
+
+// newSnapshot captures the stored fields of a task, leaving out its dependencies.
+func newSnapshot(task *models.Task) *taskSnapshot {
+	return &taskSnapshot{ID: task.ID, Title: task.Title, Notes: task.Notes, Status: task.Status, StatusReason: task.StatusReason, CreatedAt: task.CreatedAt, CompletedAt: task.CompletedAt,
+		Priority: task.Priority, Tags: task.Tags, DueAt: task.DueAt, Recurrence: task.Recurrence, ParentID: task.ParentID, ProjectID: task.ProjectID, ArchivedAt: task.ArchivedAt, DeletedAt: task.DeletedAt, Estimate: task.Estimate,
+		WaitUntil: task.WaitUntil, ResurfacedAt: task.ResurfacedAt}
+}
+
+// loadSnapshot reads the current state of a task within a transaction, returning nil if it does not exist.
+func loadSnapshot(ctx context.Context, tx *sql.Tx, id int) (*taskSnapshot, error) {
+	task, err := scanTask(tx.QueryRowContext(ctx, "SELECT "+taskColumns+" FROM tasks WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := newSnapshot(task)
+	if snapshot.DependsOn, err = queryIDs(ctx, tx, "SELECT depends_on_id FROM task_dependencies WHERE task_id = ? ORDER BY depends_on_id", id); err != nil {
+		return nil, err
+	}
+	if snapshot.Dependents, err = queryIDs(ctx, tx, "SELECT task_id FROM task_dependencies WHERE depends_on_id = ? ORDER BY task_id", id); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// loadSnapshots reads the current state of several tasks, skipping those that do not exist.
+func loadSnapshots(ctx context.Context, tx *sql.Tx, ids []int) ([]*taskSnapshot, error) {
+	var snapshots []*taskSnapshot
+	for _, id := range ids {
+		snapshot, err := loadSnapshot(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot != nil {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots, nil
+}
+
+// loadTimeData adds the time entries and pomodoros of a task to its snapshot, so that they can be put back
+// when a purge is undone.
+func loadTimeData(ctx context.Context, tx *sql.Tx, snapshot *taskSnapshot) error {
+	rows, err := tx.QueryContext(ctx, "SELECT id, started_at, ended_at, note FROM time_entries WHERE task_id = ? ORDER BY id", snapshot.ID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var entry timeEntrySnapshot
+		if err := rows.Scan(&entry.ID, &entry.StartedAt, &entry.EndedAt, &entry.Note); err != nil {
+			rows.Close()
+			return err
+		}
+		snapshot.TimeEntries = append(snapshot.TimeEntries, entry)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = tx.QueryContext(ctx, "SELECT id, time_entry_id, started_at, ended_at, planned_seconds, completed FROM pomodoros WHERE task_id = ? ORDER BY id", snapshot.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var pomodoro pomodoroSnapshot
+		if err := rows.Scan(&pomodoro.ID, &pomodoro.TimeEntryID, &pomodoro.StartedAt, &pomodoro.EndedAt, &pomodoro.PlannedSeconds, &pomodoro.Completed); err != nil {
+			return err
+		}
+		snapshot.Pomodoros = append(snapshot.Pomodoros, pomodoro)
+	}
+	return rows.Err()
+}
+
+// restoreTimeData puts back the time entries and pomodoros kept in the snapshot of a re-created task, with their original IDs.
+func restoreTimeData(ctx context.Context, tx *sql.Tx, snapshot *taskSnapshot) error {
+	for _, entry := range snapshot.TimeEntries {
+		query := "INSERT INTO time_entries (id, task_id, started_at, ended_at, note) VALUES (?, ?, ?, ?, ?)"
+		if _, err := tx.ExecContext(ctx, query, entry.ID, snapshot.ID, utc(&entry.StartedAt), utc(entry.EndedAt), entry.Note); err != nil {
+			return err
+		}
+	}
+	for _, pomodoro := range snapshot.Pomodoros {
+		query := "INSERT INTO pomodoros (id, task_id, time_entry_id, started_at, ended_at, planned_seconds, completed) VALUES (?, ?, ?, ?, ?, ?, ?)"
+		if _, err := tx.ExecContext(ctx, query, pomodoro.ID, snapshot.ID, pomodoro.TimeEntryID, utc(&pomodoro.StartedAt), utc(&pomodoro.EndedAt),
+			pomodoro.PlannedSeconds, pomodoro.Completed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateSnapshots runs fn, which changes the given tasks, and records an event for each task it changed.
+func updateSnapshots(ctx context.Context, tx *sql.Tx, ids []int, fn func() error) error {
+	before, err := loadSnapshots(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	for _, snapshot := range before {
+		after, err := loadSnapshot(ctx, tx, snapshot.ID)
+		if err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, snapshot, after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordEvent appends the change from before to after to the audit trail within the transaction making
+// it, as part of the operation in ctx if there is one. Either snapshot may be nil for a created or
+// permanently deleted task. Updates that change nothing are not recorded.
+func recordEvent(ctx context.Context, tx *sql.Tx, before, after *taskSnapshot) error {
+	eventType, current := models.EventUpdated, after
+	switch {
+	case before == nil:
+		eventType = models.EventCreated
+	case after == nil:
+		eventType, current = models.EventPurged, before
+	case before.DeletedAt == nil && after.DeletedAt != nil:
+		eventType = models.EventDeleted
+	case before.DeletedAt != nil && after.DeletedAt == nil:
+		eventType = models.EventRestored
+	}
+
+	changes := models.DiffTasks(before.task(), after.task())
+	changes = append(changes, dependencyChanges(before, after)...)
+	if eventType == models.EventUpdated && len(changes) == 0 {
+		return nil
+	}
+	if changes == nil {
+		changes = []models.FieldChange{}
+	}
+
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	encodedBefore, err := encodeSnapshot(before)
+	if err != nil {
+		return err
+	}
+	encodedAfter, err := encodeSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	var operationID *int
+	if id, ok := ctx.Value(operationKey{}).(int); ok {
+		operationID = &id
+	}
+
+	query := `INSERT INTO task_events (task_id, type, title, changes, actor, created_at, operation_id, snapshot_before, snapshot_after)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	now := time.Now()
+	_, err = tx.ExecContext(ctx, query, current.ID, eventType, current.Title, string(encoded), actor(), utc(&now), operationID, encodedBefore, encodedAfter)
+	return err
+}
+
+// dependencyChanges lists the dependencies added and removed between two versions of an existing task.
+func dependencyChanges(before, after *taskSnapshot) []models.FieldChange {
+	if before == nil || after == nil {
+		return nil
+	}
+
+	var changes []models.FieldChange
+	for _, id := range after.DependsOn {
+		if !slices.Contains(before.DependsOn, id) {
+			changes = append(changes, models.FieldChange{Field: "depends_on", New: strconv.Itoa(id)})
+		}
+	}
+	for _, id := range before.DependsOn {
+		if !slices.Contains(after.DependsOn, id) {
+			changes = append(changes, models.FieldChange{Field: "depends_on", Old: strconv.Itoa(id)})
+		}
+	}
+	return changes
+}
+
+func encodeSnapshot(snapshot *taskSnapshot) (*string, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	value := string(encoded)
+	return &value, nil
+}
+
+func decodeSnapshot(encoded sql.NullString) (*taskSnapshot, error) {
+	if !encoded.Valid {
+		return nil, nil
+	}
+	var snapshot taskSnapshot
+	if err := json.Unmarshal([]byte(encoded.String), &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+func queryIDs(ctx context.Context, tx *sql.Tx, query string, args ...any) ([]int, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// actor returns the name of the user running the command.
+func actor() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	return os.Getenv("USER")
+}
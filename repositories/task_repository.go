@@ -4,23 +4,119 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kkumar-gcc/todo/models"
 )
 
 var (
-	ErrTaskNotFound = errors.New("task not found")
+	ErrTaskNotFound       = errors.New("task not found")
+	ErrDependencyNotFound = errors.New("dependency not found")
+	ErrInvalidSearchQuery = errors.New("invalid search query")
+	ErrSearchIndexMissing = errors.New("search index does not exist")
 )
 
+var taskColumns = `id, title, notes, status, status_reason, created_at, completed_at, priority,
+    COALESCE((SELECT GROUP_CONCAT(name, ',') FROM (
+        SELECT tags.name FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE task_tags.task_id = tasks.id ORDER BY tags.name
+    )), ''),
+    due_at, recurrence, parent_id, project_id, archived_at, deleted_at, estimate_seconds, estimate_points, wait_until, resurfaced_at,
+    (SELECT COUNT(*) FROM tasks AS subtask WHERE subtask.parent_id = tasks.id AND subtask.deleted_at IS NULL),
+    (SELECT COUNT(*) FROM tasks AS subtask WHERE subtask.parent_id = tasks.id AND subtask.deleted_at IS NULL AND subtask.status IN ` + doneStatuses + `),
+    (SELECT GROUP_CONCAT(blocker.id) FROM task_dependencies AS dependency JOIN tasks AS blocker ON blocker.id = dependency.depends_on_id
+        WHERE dependency.task_id = tasks.id AND blocker.deleted_at IS NULL AND blocker.status NOT IN ` + doneStatuses + `),
+    COALESCE((SELECT name FROM projects WHERE projects.id = tasks.project_id), ''),
+    COALESCE((SELECT label FROM statuses WHERE statuses.id = tasks.status), ''),
+    COALESCE((SELECT color FROM statuses WHERE statuses.id = tasks.status), ''),
+    COALESCE((SELECT category FROM statuses WHERE statuses.id = tasks.status), ''),
+    (SELECT COALESCE(SUM(strftime('%s', COALESCE(ended_at, 'now')) - strftime('%s', started_at)), 0) FROM time_entries WHERE time_entries.task_id = tasks.id),
+    EXISTS (SELECT 1 FROM time_entries WHERE time_entries.task_id = tasks.id AND ended_at IS NULL)`
+
+// taskSortColumns maps the sort options accepted by GetAll to their ORDER BY clauses.
+var taskSortColumns = map[string]string{
+	"status":   "(SELECT position FROM statuses WHERE statuses.id = tasks.status)",
+	"priority": "priority",
+	"due":      "due_at IS NULL, due_at",
+	"created":  "created_at",
+	"deleted":  "deleted_at DESC",
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask scans a row selected with taskColumns, followed by any extra columns into extra.
+func scanTask(row scanner, extra ...any) (*models.Task, error) {
+	var task models.Task
+	var tags string
+	var blockedBy sql.NullString
+	var trackedSeconds, estimateSeconds int64
+	dest := []any{&task.ID, &task.Title, &task.Notes, &task.Status, &task.StatusReason, &task.CreatedAt, &task.CompletedAt, &task.Priority, &tags, &task.DueAt, &task.Recurrence, &task.ParentID, &task.ProjectID,
+		&task.ArchivedAt, &task.DeletedAt, &estimateSeconds, &task.Estimate.Points, &task.WaitUntil, &task.ResurfacedAt, &task.SubtaskCount, &task.CompletedSubtasks, &blockedBy, &task.Project, &task.StatusLabel, &task.StatusColor, &task.StatusCategory, &trackedSeconds, &task.Tracking}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	task.TrackedTime = time.Duration(trackedSeconds) * time.Second
+	task.Estimate.Duration = time.Duration(estimateSeconds) * time.Second
+
+	if tags != "" {
+		task.Tags = strings.Split(tags, ",")
+	}
+
+	if blockedBy.Valid {
+		for _, id := range strings.Split(blockedBy.String, ",") {
+			if blocker, err := strconv.Atoi(id); err == nil {
+				task.BlockedBy = append(task.BlockedBy, blocker)
+			}
+		}
+	}
+
+	return &task, nil
+}
+
+// utc normalizes a timestamp before it is stored so that stored values compare correctly as text.
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC().Truncate(time.Second)
+	return &u
+}
+
+// seconds converts a duration to the whole seconds it is stored as.
+func seconds(d time.Duration) int64 {
+	return int64(d / time.Second)
+}
+
 // TaskRepository defines the methods that the Task repository should implement.
 type TaskRepository interface {
 	Create(ctx context.Context, task *models.Task) error
+	CreateTree(ctx context.Context, nodes []models.TaskNode) error
+	CreateBulk(ctx context.Context, tasks []*models.Task) error
 	Delete(ctx context.Context, id int) error
 	DeleteBulk(ctx context.Context, ids []int) error
-	GetAll(ctx context.Context, status, priority int, sort string) ([]models.Task, error)
+	DeleteAndReparent(ctx context.Context, ids []int) error
+	Restore(ctx context.Context, ids []int) ([]int, error)
+	Purge(ctx context.Context, deletedBefore *time.Time) (int, error)
+	Archive(ctx context.Context, ids []int) ([]int, error)
+	ArchiveCompleted(ctx context.Context, completedBefore time.Time) ([]int, error)
+	Unarchive(ctx context.Context, ids []int) ([]int, error)
+	RemapPriorities(ctx context.Context, remap func(priority int) int) ([]int, error)
+	GetAll(ctx context.Context, filter models.TaskFilter) ([]models.Task, error)
+	GetEstimatedCompleted(ctx context.Context, from, to time.Time) ([]models.Task, error)
+	Search(ctx context.Context, query string, filter models.TaskFilter) ([]models.SearchResult, error)
 	GetByID(ctx context.Context, id int) (*models.Task, error)
-	Update(ctx context.Context, id int, updateFunc func(task *models.Task) (*models.Task, error)) error
+	Update(ctx context.Context, id int, updateFunc func(task *models.Task) (updated *models.Task, created *models.Task, err error)) error
+	CountOpenSubtasks(ctx context.Context, id int) (int, error)
+	GetDescendantIDs(ctx context.Context, ids []int) ([]int, error)
+	AddDependencies(ctx context.Context, id int, dependsOn []int) error
+	RemoveDependencies(ctx context.Context, id int, dependsOn []int) error
+	GetDependencies(ctx context.Context) (map[int][]int, error)
 }
 
 type TaskRepositoryImpl struct {
@@ -34,70 +130,398 @@ func NewTaskRepository(db *sql.DB) TaskRepository {
 }
 
 func (r *TaskRepositoryImpl) Create(ctx context.Context, task *models.Task) error {
-	query := `INSERT INTO tasks (title, status, completed_at, priority, tags)
-              VALUES (?, ?, ?, ?, ?)`
-	_, err := r.db.ExecContext(ctx, query, task.Title, task.Status, task.CompletedAt, task.Priority, task.Tags)
-	return err
+	return transaction(ctx, r.db, func(tx *sql.Tx) error {
+		return insertTask(ctx, tx, task)
+	})
 }
 
-func (r *TaskRepositoryImpl) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM tasks WHERE id = ?"
-	result, err := r.db.ExecContext(ctx, query, id)
+// CreateTree creates the tasks and their subtasks in a single transaction, so either all of them are
+// created or none are. Subtasks get the ID of the task above them as their parent.
+func (r *TaskRepositoryImpl) CreateTree(ctx context.Context, nodes []models.TaskNode) error {
+	return transaction(ctx, r.db, func(tx *sql.Tx) error {
+		return insertTree(ctx, tx, nodes, nil)
+	})
+}
+
+func insertTree(ctx context.Context, tx *sql.Tx, nodes []models.TaskNode, parentID *int) error {
+	for _, node := range nodes {
+		if parentID != nil {
+			node.Task.ParentID = parentID
+		}
+		if err := insertTask(ctx, tx, node.Task); err != nil {
+			return err
+		}
+		if err := insertTree(ctx, tx, node.Subtasks, &node.Task.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateBulk creates many tasks in a single transaction, preparing the statements once for all of
+// them. Either every task is created or none are.
+func (r *TaskRepositoryImpl) CreateBulk(ctx context.Context, tasks []*models.Task) error {
+	return transaction(ctx, r.db, func(tx *sql.Tx) error {
+		insert, err := tx.PrepareContext(ctx, insertTaskQuery)
+		if err != nil {
+			return err
+		}
+		defer insert.Close()
+		insertTag, err := tx.PrepareContext(ctx, "INSERT OR IGNORE INTO tags (name) VALUES (?)")
+		if err != nil {
+			return err
+		}
+		defer insertTag.Close()
+		linkTag, err := tx.PrepareContext(ctx, "INSERT OR IGNORE INTO task_tags (task_id, tag_id) SELECT ?, id FROM tags WHERE name = ?")
+		if err != nil {
+			return err
+		}
+		defer linkTag.Close()
+		selectTask, err := tx.PrepareContext(ctx, "SELECT "+taskColumns+" FROM tasks WHERE id = ?")
+		if err != nil {
+			return err
+		}
+		defer selectTask.Close()
+
+		for _, task := range tasks {
+			result, err := insert.ExecContext(ctx, insertTaskArgs(task)...)
+			if err != nil {
+				return err
+			}
+			id, err := result.LastInsertId()
+			if err != nil {
+				return err
+			}
+			task.ID = int(id)
+
+			for _, tag := range task.Tags {
+				if _, err := insertTag.ExecContext(ctx, tag); err != nil {
+					return err
+				}
+				if _, err := linkTag.ExecContext(ctx, task.ID, tag); err != nil {
+					return err
+				}
+			}
+
+			// A new task has no dependencies yet, so its snapshot is read without looking them up.
+			created, err := scanTask(selectTask.QueryRowContext(ctx, task.ID))
+			if err != nil {
+				return err
+			}
+			if err := recordEvent(ctx, tx, nil, newSnapshot(created)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+const insertTaskQuery = `INSERT INTO tasks (title, notes, status, status_reason, completed_at, priority, due_at, recurrence, parent_id, project_id, estimate_seconds, estimate_points, wait_until)
+                         VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+func insertTaskArgs(task *models.Task) []any {
+	return []any{task.Title, task.Notes, task.Status, task.StatusReason, utc(task.CompletedAt), task.Priority, utc(task.DueAt), task.Recurrence, task.ParentID, task.ProjectID,
+		seconds(task.Estimate.Duration), task.Estimate.Points, utc(task.WaitUntil)}
+}
+
+// insertTask inserts a task with its tags and records its creation in the task's history.
+func insertTask(ctx context.Context, tx *sql.Tx, task *models.Task) error {
+	result, err := tx.ExecContext(ctx, insertTaskQuery, insertTaskArgs(task)...)
 	if err != nil {
 		return err
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return ErrTaskNotFound
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
 	}
+	task.ID = int(id)
 
-	return nil
+	if err := syncTags(ctx, tx, task.ID, task.Tags); err != nil {
+		return err
+	}
+
+	after, err := loadSnapshot(ctx, tx, task.ID)
+	if err != nil {
+		return err
+	}
+	return recordEvent(ctx, tx, nil, after)
+}
+
+func (r *TaskRepositoryImpl) Delete(ctx context.Context, id int) error {
+	return r.DeleteBulk(ctx, []int{id})
 }
 
+// DeleteBulk moves the given tasks to the trash. Trashed tasks keep their data but are hidden from
+// every other method until they are restored.
 func (r *TaskRepositoryImpl) DeleteBulk(ctx context.Context, ids []int) error {
 	if len(ids) == 0 {
 		return nil
 	}
 
-	query := "DELETE FROM tasks WHERE id IN (" + strings.Repeat("?,", len(ids)-1) + "?)"
+	return transaction(ctx, r.db, func(tx *sql.Tx) error {
+		return trashTasks(ctx, tx, ids)
+	})
+}
 
-	args := make([]any, len(ids))
-	for i, id := range ids {
-		args[i] = id
+// DeleteAndReparent moves the given tasks to the trash and their subtasks up to the nearest ancestor that
+// is not among them, in one transaction so that the tree is left as it was when the delete fails.
+func (r *TaskRepositoryImpl) DeleteAndReparent(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return nil
 	}
 
-	result, err := r.db.ExecContext(ctx, query, args...)
+	return transaction(ctx, r.db, func(tx *sql.Tx) error {
+		if err := checkLive(ctx, tx, ids); err != nil {
+			return err
+		}
+		if err := reparentSubtasks(ctx, tx, ids); err != nil {
+			return err
+		}
+		return trashTasks(ctx, tx, ids)
+	})
+}
+
+// checkLive returns ErrTaskNotFound unless every given task exists and is not in the trash.
+func checkLive(ctx context.Context, tx *sql.Tx, ids []int) error {
+	ids = uniqueIDs(ids)
+	liveIDs, err := queryIDs(ctx, tx, "SELECT id FROM tasks WHERE id IN ("+placeholders(len(ids))+") AND deleted_at IS NULL", intArgs(ids)...)
 	if err != nil {
 		return err
 	}
-
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
+	if len(liveIDs) != len(ids) {
 		return ErrTaskNotFound
 	}
-
 	return nil
 }
 
-func (r *TaskRepositoryImpl) GetAll(ctx context.Context, status, priority int, sort string) ([]models.Task, error) {
-	query := "SELECT id, title, status, created_at, completed_at, priority, tags FROM tasks WHERE 1=1"
+// trashTasks sets the deleted_at of the given tasks, which must all be live.
+func trashTasks(ctx context.Context, tx *sql.Tx, ids []int) error {
+	ids = uniqueIDs(ids)
+	if err := checkLive(ctx, tx, ids); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	query := "UPDATE tasks SET deleted_at = ? WHERE id IN (" + placeholders(len(ids)) + ")"
+	return updateSnapshots(ctx, tx, ids, func() error {
+		_, err := tx.ExecContext(ctx, query, append([]any{utc(&now)}, intArgs(ids)...)...)
+		return err
+	})
+}
+
+// Restore takes the given tasks out of the trash, together with the subtasks that were trashed with
+// them and any trashed parents, so that they return to the same place in the task tree. It returns
+// the IDs of every restored task.
+func (r *TaskRepositoryImpl) Restore(ctx context.Context, ids []int) ([]int, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `WITH RECURSIVE
+                  descendants(id, deleted_at) AS (
+                      SELECT id, deleted_at FROM tasks WHERE id IN (` + placeholders(len(ids)) + `) AND deleted_at IS NOT NULL
+                      UNION
+                      SELECT tasks.id, tasks.deleted_at FROM tasks JOIN descendants ON tasks.parent_id = descendants.id AND tasks.deleted_at = descendants.deleted_at
+                  ),
+                  ancestors(id) AS (
+                      SELECT parent_id FROM tasks WHERE id IN (` + placeholders(len(ids)) + `) AND deleted_at IS NOT NULL
+                      UNION
+                      SELECT tasks.parent_id FROM tasks JOIN ancestors ON tasks.id = ancestors.id WHERE tasks.deleted_at IS NOT NULL
+                  )
+              SELECT id FROM descendants
+              UNION
+              SELECT tasks.id FROM tasks JOIN ancestors ON tasks.id = ancestors.id WHERE tasks.deleted_at IS NOT NULL`
 
+	var restoredIDs []int
+	err := transaction(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		restoredIDs, err = queryIDs(ctx, tx, query, append(intArgs(ids), intArgs(ids)...)...)
+		if err != nil {
+			return err
+		}
+		if len(restoredIDs) == 0 {
+			return ErrTaskNotFound
+		}
+
+		return updateSnapshots(ctx, tx, restoredIDs, func() error {
+			_, err := tx.ExecContext(ctx, "UPDATE tasks SET deleted_at = NULL WHERE id IN ("+placeholders(len(restoredIDs))+")", intArgs(restoredIDs)...)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restoredIDs, nil
+}
+
+// Purge permanently deletes the tasks in the trash, or only those trashed before deletedBefore when
+// it is not nil, and returns how many were deleted.
+func (r *TaskRepositoryImpl) Purge(ctx context.Context, deletedBefore *time.Time) (int, error) {
+	query := "SELECT id FROM tasks WHERE deleted_at IS NOT NULL"
 	var args []any
-	if status != 0 {
-		query += " AND status = ?"
-		args = append(args, status)
+	if deletedBefore != nil {
+		query += " AND deleted_at < ?"
+		args = append(args, utc(deletedBefore))
 	}
 
-	if priority != 0 {
-		query += " AND priority = ?"
-		args = append(args, priority)
+	var purged int
+	err := transaction(ctx, r.db, func(tx *sql.Tx) error {
+		ids, err := queryIDs(ctx, tx, query, args...)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		snapshots, err := loadSnapshots(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		// Time entries and pomodoros are deleted with their tasks, so keep them for undo.
+		for _, snapshot := range snapshots {
+			if err := loadTimeData(ctx, tx, snapshot); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM tasks WHERE id IN ("+placeholders(len(ids))+")", intArgs(ids)...); err != nil {
+			return err
+		}
+
+		for _, snapshot := range snapshots {
+			if err := recordEvent(ctx, tx, snapshot, nil); err != nil {
+				return err
+			}
+		}
+		purged = len(ids)
+
+		return nil
+	})
+
+	return purged, err
+}
+
+// Archive archives the given tasks that are completed and returns the IDs of the tasks it archived.
+func (r *TaskRepositoryImpl) Archive(ctx context.Context, ids []int) ([]int, error) {
+	if len(ids) == 0 {
+		return nil, nil
 	}
 
-	if sort != "" {
-		query += " ORDER BY " + sort
+	return r.archive(ctx, "id IN ("+placeholders(len(ids))+")", intArgs(ids)...)
+}
+
+// ArchiveCompleted archives every task completed before the given moment and returns their IDs.
+func (r *TaskRepositoryImpl) ArchiveCompleted(ctx context.Context, completedBefore time.Time) ([]int, error) {
+	return r.archive(ctx, "completed_at < ?", utc(&completedBefore))
+}
+
+// archive archives the completed, active tasks matching the condition.
+func (r *TaskRepositoryImpl) archive(ctx context.Context, condition string, args ...any) ([]int, error) {
+	query := "SELECT id FROM tasks WHERE " + condition + " AND status IN " + doneStatuses + " AND archived_at IS NULL AND deleted_at IS NULL"
+
+	now := time.Now()
+	return r.setArchivedAt(ctx, query, args, utc(&now))
+}
+
+// Unarchive returns the given archived tasks to the active list and returns the IDs of the tasks it changed.
+func (r *TaskRepositoryImpl) Unarchive(ctx context.Context, ids []int) ([]int, error) {
+	if len(ids) == 0 {
+		return nil, nil
 	}
 
+	query := "SELECT id FROM tasks WHERE id IN (" + placeholders(len(ids)) + ") AND archived_at IS NOT NULL AND deleted_at IS NULL"
+	return r.setArchivedAt(ctx, query, intArgs(ids), nil)
+}
+
+// setArchivedAt sets archived_at on the tasks selected by query.
+func (r *TaskRepositoryImpl) setArchivedAt(ctx context.Context, query string, args []any, archivedAt *time.Time) ([]int, error) {
+	var ids []int
+	err := transaction(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		ids, err = queryIDs(ctx, tx, query, args...)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		return updateSnapshots(ctx, tx, ids, func() error {
+			_, err := tx.ExecContext(ctx, "UPDATE tasks SET archived_at = ? WHERE id IN ("+placeholders(len(ids))+")", append([]any{archivedAt}, intArgs(ids)...)...)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// RemapPriorities sets the priority of every task, including those in the trash, to the one remap
+// returns for it, and returns the IDs of the tasks it changed.
+func (r *TaskRepositoryImpl) RemapPriorities(ctx context.Context, remap func(priority int) int) ([]int, error) {
+	var ids []int
+	err := transaction(ctx, r.db, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, "SELECT id, priority FROM tasks ORDER BY id")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		priorities := make(map[int]int)
+		for rows.Next() {
+			var id, priority int
+			if err := rows.Scan(&id, &priority); err != nil {
+				return err
+			}
+			if remapped := remap(priority); remapped != priority {
+				ids = append(ids, id)
+				priorities[id] = remapped
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		return updateSnapshots(ctx, tx, ids, func() error {
+			for _, id := range ids {
+				if _, err := tx.ExecContext(ctx, "UPDATE tasks SET priority = ? WHERE id = ?", priorities[id], id); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *TaskRepositoryImpl) GetAll(ctx context.Context, filter models.TaskFilter) ([]models.Task, error) {
+	conditions, args := filterConditions(filter)
+	query := "SELECT " + taskColumns + " FROM tasks WHERE 1=1" + conditions
+
+	if column, ok := taskSortColumns[filter.Sort]; ok {
+		query += " ORDER BY " + column
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+// GetEstimatedCompleted returns the estimated tasks completed in [from, to), including archived ones
+// and those in archived projects.
+func (r *TaskRepositoryImpl) GetEstimatedCompleted(ctx context.Context, from, to time.Time) ([]models.Task, error) {
+	query := "SELECT " + taskColumns + ` FROM tasks
+        WHERE deleted_at IS NULL AND status IN ` + doneStatuses + ` AND completed_at >= ? AND completed_at < ? AND (estimate_seconds > 0 OR estimate_points > 0)
+        ORDER BY completed_at`
+	return r.query(ctx, query, utc(&from), utc(&to))
+}
+
+// query returns the tasks selected with taskColumns by query.
+func (r *TaskRepositoryImpl) query(ctx context.Context, query string, args ...any) ([]models.Task, error) {
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
@@ -106,30 +530,72 @@ func (r *TaskRepositoryImpl) GetAll(ctx context.Context, status, priority int, s
 
 	var tasks []models.Task
 	for rows.Next() {
-		var task models.Task
-		err := rows.Scan(&task.ID, &task.Title, &task.Status, &task.CreatedAt, &task.CompletedAt, &task.Priority, &task.Tags)
+		task, err := scanTask(rows)
 		if err != nil {
 			return nil, err
 		}
-		tasks = append(tasks, task)
+		tasks = append(tasks, *task)
 	}
 
-	return tasks, nil
+	return tasks, rows.Err()
 }
 
-func (r *TaskRepositoryImpl) GetByID(ctx context.Context, id int) (*models.Task, error) {
-	query := "SELECT id, title, status, created_at, completed_at, priority, tags FROM tasks WHERE id = ?"
-	row := r.db.QueryRowContext(ctx, query, id)
+// Search runs a full-text query against task titles, tags and notes, best matches first.
+func (r *TaskRepositoryImpl) Search(ctx context.Context, query string, filter models.TaskFilter) ([]models.SearchResult, error) {
+	conditions, args := filterConditions(filter)
+	sqlQuery := "SELECT " + taskColumns + `, search.snippet FROM tasks
+        JOIN (
+            SELECT rowid AS task_id, snippet(tasks_search, -1, ?, ?, '…', 12) AS snippet, bm25(tasks_search, 10.0, 5.0, 1.0) AS score
+            FROM tasks_search WHERE tasks_search MATCH ?
+        ) AS search ON search.task_id = tasks.id
+        WHERE 1=1` + conditions + " ORDER BY search.score"
+	args = append([]any{models.HighlightStart, models.HighlightEnd, query}, args...)
 
-	var task models.Task
-	err := row.Scan(&task.ID, &task.Title, &task.Status, &task.CreatedAt, &task.CompletedAt, &task.Priority, &task.Tags)
+	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
-		return nil, err
+		return nil, searchError(err)
 	}
-	return &task, nil
+	defer rows.Close()
+
+	var results []models.SearchResult
+	for rows.Next() {
+		var snippet string
+		task, err := scanTask(rows, &snippet)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, models.SearchResult{Task: *task, Snippet: snippet})
+	}
+
+	return results, searchError(rows.Err())
 }
 
-func (r *TaskRepositoryImpl) Update(ctx context.Context, id int, updateFunc func(task *models.Task) (*models.Task, error)) error {
+// searchError translates the SQLite errors caused by a bad query or a missing index.
+func searchError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	switch {
+	// FTS5 reports malformed queries as syntax errors, and unknown column filters as missing columns.
+	case strings.Contains(message, "fts5:") || strings.Contains(message, "unterminated string") || strings.Contains(message, "no such column"):
+		return ErrInvalidSearchQuery
+	case strings.Contains(message, "no such table: tasks_search") || strings.Contains(message, "no such module: fts5"):
+		return ErrSearchIndexMissing
+	default:
+		return err
+	}
+}
+
+func (r *TaskRepositoryImpl) GetByID(ctx context.Context, id int) (*models.Task, error) {
+	query := "SELECT " + taskColumns + " FROM tasks WHERE id = ? AND deleted_at IS NULL"
+	return scanTask(r.db.QueryRowContext(ctx, query, id))
+}
+
+// Update saves the changes updateFunc makes to a task. updateFunc may also return a new task, such as the
+// next occurrence of a recurring task, which is created in the same transaction as the change.
+func (r *TaskRepositoryImpl) Update(ctx context.Context, id int, updateFunc func(task *models.Task) (updated *models.Task, created *models.Task, err error)) error {
 	task, err := r.GetByID(ctx, id)
 	if err != nil {
 		return err
@@ -138,12 +604,326 @@ func (r *TaskRepositoryImpl) Update(ctx context.Context, id int, updateFunc func
 		return ErrTaskNotFound
 	}
 
-	updatedTask, err := updateFunc(task)
+	updatedTask, createdTask, err := updateFunc(task)
+	if err != nil {
+		return err
+	}
+
+	return transaction(ctx, r.db, func(tx *sql.Tx) error {
+		before, err := loadSnapshot(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		query := `UPDATE tasks SET title = ?, notes = ?, status = ?, status_reason = ?, completed_at = ?, priority = ?, due_at = ?, recurrence = ?, parent_id = ?, project_id = ?, archived_at = ?,
+                  estimate_seconds = ?, estimate_points = ?, wait_until = ?, resurfaced_at = ? WHERE id = ?`
+		_, err = tx.ExecContext(ctx, query, updatedTask.Title, updatedTask.Notes, updatedTask.Status, updatedTask.StatusReason, utc(updatedTask.CompletedAt), updatedTask.Priority, utc(updatedTask.DueAt), updatedTask.Recurrence, updatedTask.ParentID, updatedTask.ProjectID, utc(updatedTask.ArchivedAt),
+			seconds(updatedTask.Estimate.Duration), updatedTask.Estimate.Points, utc(updatedTask.WaitUntil), utc(updatedTask.ResurfacedAt), id)
+		if err != nil {
+			return err
+		}
+
+		if err := syncTags(ctx, tx, id, updatedTask.Tags); err != nil {
+			return err
+		}
+
+		after, err := loadSnapshot(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, before, after); err != nil {
+			return err
+		}
+
+		if createdTask == nil {
+			return nil
+		}
+		return insertTask(ctx, tx, createdTask)
+	})
+}
+
+func (r *TaskRepositoryImpl) CountOpenSubtasks(ctx context.Context, id int) (int, error) {
+	query := "SELECT COUNT(*) FROM tasks WHERE parent_id = ? AND deleted_at IS NULL AND status NOT IN " + doneStatuses
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&count)
+	return count, err
+}
+
+// GetDescendantIDs returns the IDs of every subtask below the given tasks, at any depth, leaving out those in the trash.
+func (r *TaskRepositoryImpl) GetDescendantIDs(ctx context.Context, ids []int) ([]int, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `WITH RECURSIVE descendants(id) AS (
+                  SELECT id FROM tasks WHERE parent_id IN (` + placeholders(len(ids)) + `) AND deleted_at IS NULL
+                  UNION
+                  SELECT tasks.id FROM tasks JOIN descendants ON tasks.parent_id = descendants.id WHERE tasks.deleted_at IS NULL
+              )
+              SELECT id FROM descendants`
+
+	rows, err := r.db.QueryContext(ctx, query, intArgs(ids)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var descendants []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		descendants = append(descendants, id)
+	}
+
+	return descendants, rows.Err()
+}
+
+// reparentSubtasks moves the live subtasks of the given tasks up to the nearest ancestor that is not among them.
+func reparentSubtasks(ctx context.Context, tx *sql.Tx, ids []int) error {
+	query := `UPDATE tasks SET parent_id = (SELECT parent.parent_id FROM tasks AS parent WHERE parent.id = tasks.parent_id)
+              WHERE parent_id IN (` + placeholders(len(ids)) + `) AND id NOT IN (` + placeholders(len(ids)) + `) AND deleted_at IS NULL`
+	args := append(intArgs(ids), intArgs(ids)...)
+
+	subtaskIDs, err := queryIDs(ctx, tx, "SELECT id FROM tasks WHERE parent_id IN ("+placeholders(len(ids))+") AND id NOT IN ("+placeholders(len(ids))+") AND deleted_at IS NULL", args...)
+	if err != nil {
+		return err
+	}
+	before, err := loadSnapshots(ctx, tx, subtaskIDs)
+	if err != nil {
+		return err
+	}
+
+	for {
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+			break
+		}
+	}
+
+	for _, snapshot := range before {
+		after, err := loadSnapshot(ctx, tx, snapshot.ID)
+		if err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, snapshot, after); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TaskRepositoryImpl) AddDependencies(ctx context.Context, id int, dependsOn []int) error {
+	return transaction(ctx, r.db, func(tx *sql.Tx) error {
+		before, err := loadSnapshot(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		query := "INSERT OR IGNORE INTO task_dependencies (task_id, depends_on_id) VALUES (?, ?)"
+		for _, dependency := range dependsOn {
+			if _, err := tx.ExecContext(ctx, query, id, dependency); err != nil {
+				return err
+			}
+		}
+
+		after, err := loadSnapshot(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, before, after)
+	})
+}
+
+func (r *TaskRepositoryImpl) RemoveDependencies(ctx context.Context, id int, dependsOn []int) error {
+	if len(dependsOn) == 0 {
+		return nil
+	}
+
+	return transaction(ctx, r.db, func(tx *sql.Tx) error {
+		before, err := loadSnapshot(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		query := "DELETE FROM task_dependencies WHERE task_id = ? AND depends_on_id IN (" + placeholders(len(dependsOn)) + ")"
+		result, err := tx.ExecContext(ctx, query, append([]any{id}, intArgs(dependsOn)...)...)
+		if err != nil {
+			return err
+		}
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+			return ErrDependencyNotFound
+		}
+
+		after, err := loadSnapshot(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, before, after)
+	})
+}
+
+// GetDependencies returns every dependency edge, keyed by the dependent task's ID.
+func (r *TaskRepositoryImpl) GetDependencies(ctx context.Context) (map[int][]int, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT task_id, depends_on_id FROM task_dependencies")
 	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dependencies := make(map[int][]int)
+	for rows.Next() {
+		var id, dependsOn int
+		if err := rows.Scan(&id, &dependsOn); err != nil {
+			return nil, err
+		}
+		dependencies[id] = append(dependencies[id], dependsOn)
+	}
+
+	return dependencies, rows.Err()
+}
+
+// filterConditions turns a TaskFilter into " AND ..." conditions on the tasks table and their arguments.
+func filterConditions(filter models.TaskFilter) (string, []any) {
+	var query string
+	var args []any
+	now := time.Now()
+	if filter.Deleted {
+		query += " AND deleted_at IS NOT NULL"
+	} else {
+		query += " AND deleted_at IS NULL"
+	}
+
+	switch {
+	case filter.Archived:
+		query += " AND archived_at IS NOT NULL"
+	case !filter.WithArchived && !filter.Deleted:
+		query += " AND archived_at IS NULL"
+	}
+
+	switch {
+	case filter.Waiting:
+		query += " AND wait_until > ?"
+		args = append(args, utc(&now))
+	case !filter.WithWaiting && !filter.Deleted:
+		query += " AND (wait_until IS NULL OR wait_until <= ?)"
+		args = append(args, utc(&now))
+	}
+
+	if filter.Status != 0 {
+		query += " AND status = ?"
+		args = append(args, filter.Status)
+	}
+
+	if filter.Priority != 0 {
+		query += " AND priority = ?"
+		args = append(args, filter.Priority)
+	}
+
+	if filter.DueBefore != nil {
+		query += " AND due_at < ?"
+		args = append(args, utc(filter.DueBefore))
+	}
+
+	if filter.Overdue {
+		query += " AND due_at < ? AND status NOT IN " + doneStatuses
+		args = append(args, utc(&now))
+	}
+
+	if filter.MaxEstimate > 0 {
+		query += " AND estimate_seconds > 0 AND estimate_seconds <= ?"
+		args = append(args, seconds(filter.MaxEstimate))
+	}
+
+	if filter.ProjectID != 0 {
+		query += " AND project_id = ?"
+		args = append(args, filter.ProjectID)
+	} else if !filter.Deleted {
+		query += " AND (project_id IS NULL OR project_id NOT IN (SELECT id FROM projects WHERE archived = 1))"
+	}
+
+	tagMatches := "SELECT task_tags.task_id FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE tags.name IN "
+
+	if len(filter.AnyTags) > 0 {
+		query += " AND id IN (" + tagMatches + "(" + placeholders(len(filter.AnyTags)) + "))"
+		args = append(args, stringArgs(filter.AnyTags)...)
+	}
+
+	if len(filter.AllTags) > 0 {
+		query += " AND (SELECT COUNT(*) FROM (" + tagMatches + "(" + placeholders(len(filter.AllTags)) + ")) AS matches WHERE matches.task_id = tasks.id) = ?"
+		args = append(args, stringArgs(filter.AllTags)...)
+		args = append(args, len(filter.AllTags))
+	}
+
+	if len(filter.NoTags) > 0 {
+		query += " AND id NOT IN (" + tagMatches + "(" + placeholders(len(filter.NoTags)) + "))"
+		args = append(args, stringArgs(filter.NoTags)...)
+	}
+
+	return query, args
+}
+
+// syncTags replaces the tags attached to a task, creating any tags that do not exist yet.
+func syncTags(ctx context.Context, tx *sql.Tx, taskID int, tags []string) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = ?", taskID); err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO tags (name) VALUES (?)", tag); err != nil {
+			return err
+		}
+		query := "INSERT OR IGNORE INTO task_tags (task_id, tag_id) SELECT ?, id FROM tags WHERE name = ?"
+		if _, err := tx.ExecContext(ctx, query, taskID, tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// transaction runs fn inside a database transaction, committing only if it succeeds.
+func transaction(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
 		return err
 	}
 
-	query := `UPDATE tasks SET title = ?, status = ?, completed_at = ?, priority = ?, tags = ? WHERE id = ?`
-	_, err = r.db.ExecContext(ctx, query, updatedTask.Title, updatedTask.Status, updatedTask.CompletedAt, updatedTask.Priority, updatedTask.Tags, id)
-	return err
+	return tx.Commit()
+}
+
+// uniqueIDs returns the IDs sorted, without duplicates.
+func uniqueIDs(ids []int) []int {
+	return slices.Compact(slices.Sorted(slices.Values(ids)))
+}
+
+func placeholders(n int) string {
+	return strings.Repeat("?,", n-1) + "?"
+}
+
+func stringArgs(values []string) []any {
+	args := make([]any, len(values))
+	for i, value := range values {
+		args[i] = value
+	}
+	return args
+}
+
+func intArgs(ids []int) []any {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return args
 }
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kkumar-gcc/todo/models"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+)
+
+// templateExtensions are the file extensions of template files, in order of preference.
+var templateExtensions = []string{".yaml", ".yml"}
+
+// TemplateRepository defines the methods that the Template repository should implement.
+type TemplateRepository interface {
+	GetAll(ctx context.Context) ([]models.Template, error)
+	GetByName(ctx context.Context, name string) (*models.Template, error)
+}
+
+// TemplateRepositoryImpl reads templates from the YAML files in a directory, each file holding one
+// template named after the file.
+type TemplateRepositoryImpl struct {
+	dir string
+}
+
+func NewTemplateRepository(dir string) TemplateRepository {
+	return &TemplateRepositoryImpl{
+		dir: dir,
+	}
+}
+
+func (r *TemplateRepositoryImpl) GetAll(ctx context.Context) ([]models.Template, error) {
+	entries, err := os.ReadDir(r.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var templates []models.Template
+	for _, entry := range entries {
+		if entry.IsDir() || !slices.Contains(templateExtensions, filepath.Ext(entry.Name())) {
+			continue
+		}
+		template, err := readTemplate(filepath.Join(r.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(templates, func(other models.Template) bool { return other.Name == template.Name }) {
+			continue
+		}
+		templates = append(templates, *template)
+	}
+
+	return templates, nil
+}
+
+func (r *TemplateRepositoryImpl) GetByName(ctx context.Context, name string) (*models.Template, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name != filepath.Base(name) {
+		return nil, ErrTemplateNotFound
+	}
+
+	for _, extension := range templateExtensions {
+		template, err := readTemplate(filepath.Join(r.dir, name+extension))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		return template, err
+	}
+
+	return nil, ErrTemplateNotFound
+}
+
+// readTemplate decodes a template file, rejecting settings it does not know so that typos are caught.
+func readTemplate(path string) (*models.Template, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var template models.Template
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&template); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid template %s: %w", path, err)
+	}
+
+	template.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	template.Path = path
+	return &template, nil
+}
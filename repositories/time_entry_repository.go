@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/kkumar-gcc/todo/models"
+)
+
+var (
+	ErrNoRunningTimer = errors.New("no timer is running")
+)
+
+var timeEntryColumns = `time_entries.id, time_entries.task_id, time_entries.started_at, time_entries.ended_at, time_entries.note, tasks.title,
+    COALESCE((SELECT GROUP_CONCAT(name, ',') FROM (
+        SELECT tags.name FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE task_tags.task_id = tasks.id ORDER BY tags.name
+    )), ''),
+    COALESCE((SELECT name FROM projects WHERE projects.id = tasks.project_id), '')`
+
+var runningTimeEntryQuery = "SELECT " + timeEntryColumns + " FROM time_entries JOIN tasks ON tasks.id = time_entries.task_id WHERE time_entries.ended_at IS NULL"
+
+// TimeEntryRepository defines the methods that the TimeEntry repository should implement.
+type TimeEntryRepository interface {
+	Start(ctx context.Context, taskID int, at time.Time) (*models.TimeEntry, error)
+	Stop(ctx context.Context, at time.Time) (*models.TimeEntry, error)
+	Create(ctx context.Context, entry *models.TimeEntry) error
+	GetRunning(ctx context.Context) (*models.TimeEntry, error)
+	GetByTaskID(ctx context.Context, taskID int) ([]models.TimeEntry, error)
+	GetBetween(ctx context.Context, from, to time.Time) ([]models.TimeEntry, error)
+}
+
+type TimeEntryRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewTimeEntryRepository(db *sql.DB) TimeEntryRepository {
+	return &TimeEntryRepositoryImpl{
+		db: db,
+	}
+}
+
+func scanTimeEntry(row scanner) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	var tags string
+	err := row.Scan(&entry.ID, &entry.TaskID, &entry.StartedAt, &entry.EndedAt, &entry.Note, &entry.Task, &tags, &entry.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	if tags != "" {
+		entry.Tags = strings.Split(tags, ",")
+	}
+
+	return &entry, nil
+}
+
+// Start starts a timer on the task, stopping the running timer first if there is one. It returns the
+// entry of the timer it stopped, or nil.
+func (r *TimeEntryRepositoryImpl) Start(ctx context.Context, taskID int, at time.Time) (*models.TimeEntry, error) {
+	var stopped *models.TimeEntry
+	err := transaction(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		stopped, err = stopRunning(ctx, tx, at)
+		if err != nil && !errors.Is(err, ErrNoRunningTimer) {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO time_entries (task_id, started_at) VALUES (?, ?)", taskID, utc(&at))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stopped, nil
+}
+
+// Stop stops the running timer and returns its entry.
+func (r *TimeEntryRepositoryImpl) Stop(ctx context.Context, at time.Time) (*models.TimeEntry, error) {
+	var stopped *models.TimeEntry
+	err := transaction(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		stopped, err = stopRunning(ctx, tx, at)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stopped, nil
+}
+
+// stopRunning ends the running timer at the given moment, or when it started if that is later.
+func stopRunning(ctx context.Context, tx *sql.Tx, at time.Time) (*models.TimeEntry, error) {
+	entry, err := scanTimeEntry(tx.QueryRowContext(ctx, runningTimeEntryQuery))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNoRunningTimer
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if at.Before(entry.StartedAt) {
+		at = entry.StartedAt
+	}
+	entry.EndedAt = utc(&at)
+
+	if _, err := tx.ExecContext(ctx, "UPDATE time_entries SET ended_at = ? WHERE id = ?", entry.EndedAt, entry.ID); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (r *TimeEntryRepositoryImpl) Create(ctx context.Context, entry *models.TimeEntry) error {
+	query := "INSERT INTO time_entries (task_id, started_at, ended_at, note) VALUES (?, ?, ?, ?)"
+	result, err := r.db.ExecContext(ctx, query, entry.TaskID, utc(&entry.StartedAt), utc(entry.EndedAt), entry.Note)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	entry.ID = int(id)
+
+	return nil
+}
+
+// GetRunning returns the entry of the running timer, or ErrNoRunningTimer.
+func (r *TimeEntryRepositoryImpl) GetRunning(ctx context.Context) (*models.TimeEntry, error) {
+	entry, err := scanTimeEntry(r.db.QueryRowContext(ctx, runningTimeEntryQuery))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNoRunningTimer
+	}
+	return entry, err
+}
+
+// GetByTaskID returns the time entries of a task, oldest first.
+func (r *TimeEntryRepositoryImpl) GetByTaskID(ctx context.Context, taskID int) ([]models.TimeEntry, error) {
+	query := "SELECT " + timeEntryColumns + " FROM time_entries JOIN tasks ON tasks.id = time_entries.task_id WHERE time_entries.task_id = ? ORDER BY time_entries.started_at, time_entries.id"
+	return r.query(ctx, query, taskID)
+}
+
+// GetBetween returns the time entries that started within [from, to), oldest first, leaving out
+// those of tasks in the trash.
+func (r *TimeEntryRepositoryImpl) GetBetween(ctx context.Context, from, to time.Time) ([]models.TimeEntry, error) {
+	query := "SELECT " + timeEntryColumns + ` FROM time_entries JOIN tasks ON tasks.id = time_entries.task_id
+              WHERE time_entries.started_at >= ? AND time_entries.started_at < ? AND tasks.deleted_at IS NULL
+              ORDER BY time_entries.started_at, time_entries.id`
+	return r.query(ctx, query, utc(&from), utc(&to))
+}
+
+func (r *TimeEntryRepositoryImpl) query(ctx context.Context, query string, args ...any) ([]models.TimeEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.TimeEntry
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/repositories"
+)
+
+type HistoryService interface {
+	GetTaskHistory(ctx context.Context, id int) ([]models.TaskEvent, error)
+	GetActivity(ctx context.Context, since time.Time) ([]models.TaskEvent, error)
+}
+
+type HistoryServiceImpl struct {
+	repository repositories.TaskEventRepository
+}
+
+// NewHistoryService creates a new instance of HistoryService
+func NewHistoryService(repo repositories.TaskEventRepository) HistoryService {
+	return &HistoryServiceImpl{
+		repository: repo,
+	}
+}
+
+// GetTaskHistory returns every recorded change to a task, oldest first. Deleted tasks keep their history.
+func (r *HistoryServiceImpl) GetTaskHistory(ctx context.Context, id int) ([]models.TaskEvent, error) {
+	if id <= 0 {
+		return nil, ErrInvalidID
+	}
+
+	return r.repository.GetByTaskID(ctx, id)
+}
+
+// GetActivity returns the changes made to any task since the given time, oldest first.
+func (r *HistoryServiceImpl) GetActivity(ctx context.Context, since time.Time) ([]models.TaskEvent, error) {
+	return r.repository.GetSince(ctx, since)
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kkumar-gcc/todo/constants"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/repositories"
+)
+
+var (
+	ErrEmptyProjectName      = errors.New("project name cannot be empty")
+	ErrInvalidProjectColor   = errors.New("project color must be one of: " + strings.Join(constants.Colors, ", "))
+	ErrProjectNotFound       = errors.New("project not found")
+	ErrProjectExists         = errors.New("a project with this name already exists")
+	ErrProjectCreationFailed = errors.New("failed to create project")
+	ErrProjectUpdateFailed   = errors.New("failed to update project")
+)
+
+type ProjectService interface {
+	CreateProject(ctx context.Context, project *models.Project) error
+	GetAllProjects(ctx context.Context, includeArchived bool) ([]models.Project, error)
+	GetProject(ctx context.Context, nameOrID string) (*models.Project, error)
+	RenameProject(ctx context.Context, nameOrID, newName string) error
+	ArchiveProject(ctx context.Context, nameOrID string, archived bool) error
+}
+
+type ProjectServiceImpl struct {
+	repository repositories.ProjectRepository
+}
+
+// NewProjectService creates a new instance of ProjectService
+func NewProjectService(repo repositories.ProjectRepository) ProjectService {
+	return &ProjectServiceImpl{
+		repository: repo,
+	}
+}
+
+func (r *ProjectServiceImpl) CreateProject(ctx context.Context, project *models.Project) error {
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		return ErrEmptyProjectName
+	}
+	if project.Color != "" && !slices.Contains(constants.Colors, project.Color) {
+		return ErrInvalidProjectColor
+	}
+	if _, err := r.repository.GetByName(ctx, project.Name); err == nil {
+		return ErrProjectExists
+	}
+
+	if err := r.repository.Create(ctx, project); err != nil {
+		return ErrProjectCreationFailed
+	}
+
+	return nil
+}
+
+func (r *ProjectServiceImpl) GetAllProjects(ctx context.Context, includeArchived bool) ([]models.Project, error) {
+	return r.repository.GetAll(ctx, includeArchived)
+}
+
+// GetProject looks a project up by its name, falling back to its numeric ID.
+func (r *ProjectServiceImpl) GetProject(ctx context.Context, nameOrID string) (*models.Project, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+	if nameOrID == "" {
+		return nil, ErrEmptyProjectName
+	}
+
+	project, err := r.repository.GetByName(ctx, nameOrID)
+	if err == nil {
+		return project, nil
+	}
+
+	if id, convErr := strconv.Atoi(nameOrID); convErr == nil {
+		if project, err = r.repository.GetByID(ctx, id); err == nil {
+			return project, nil
+		}
+	}
+
+	return nil, ErrProjectNotFound
+}
+
+func (r *ProjectServiceImpl) RenameProject(ctx context.Context, nameOrID, newName string) error {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return ErrEmptyProjectName
+	}
+
+	project, err := r.GetProject(ctx, nameOrID)
+	if err != nil {
+		return err
+	}
+	if existing, err := r.repository.GetByName(ctx, newName); err == nil && existing.ID != project.ID {
+		return ErrProjectExists
+	}
+
+	err = r.repository.Update(ctx, project.ID, func(project *models.Project) (*models.Project, error) {
+		project.Name = newName
+		return project, nil
+	})
+	if err != nil {
+		return ErrProjectUpdateFailed
+	}
+
+	return nil
+}
+
+func (r *ProjectServiceImpl) ArchiveProject(ctx context.Context, nameOrID string, archived bool) error {
+	project, err := r.GetProject(ctx, nameOrID)
+	if err != nil {
+		return err
+	}
+
+	err = r.repository.Update(ctx, project.ID, func(project *models.Project) (*models.Project, error) {
+		project.Archived = archived
+		return project, nil
+	})
+	if err != nil {
+		return ErrProjectUpdateFailed
+	}
+
+	return nil
+}
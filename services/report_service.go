@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/repositories"
+)
+
+var (
+	ErrInvalidReportGroup = errors.New("group-by must be one of: task, tag, project, day, week")
+	ErrInvalidRounding    = errors.New("rounding must be one of: up, down, nearest")
+	ErrInvalidReportRange = errors.New("the start of the report must be before its end")
+	ErrInvalidActual      = errors.New("actual must be one of: tracked, elapsed")
+)
+
+const (
+	untaggedGroup  = "(untagged)"
+	noProjectGroup = "(no project)"
+)
+
+type ReportService interface {
+	GetTimeReport(ctx context.Context, options models.TimeReportOptions) (*models.TimeReport, error)
+	GetEstimateReport(ctx context.Context, options models.EstimateReportOptions) (*models.EstimateReport, error)
+}
+
+type ReportServiceImpl struct {
+	timeEntries repositories.TimeEntryRepository
+	tasks       repositories.TaskRepository
+}
+
+// NewReportService creates a new instance of ReportService
+func NewReportService(timeEntries repositories.TimeEntryRepository, tasks repositories.TaskRepository) ReportService {
+	return &ReportServiceImpl{
+		timeEntries: timeEntries,
+		tasks:       tasks,
+	}
+}
+
+// GetTimeReport totals the time entries that started in the report's period. Each row is the time spent
+// on a task within a group, rounded on its own so that subtotals and the total add up to what is billed.
+// A running timer counts up to now.
+func (r *ReportServiceImpl) GetTimeReport(ctx context.Context, options models.TimeReportOptions) (*models.TimeReport, error) {
+	if options.GroupBy == "" {
+		options.GroupBy = models.ReportByTask
+	}
+	if options.Rounding == "" {
+		options.Rounding = models.RoundUp
+	}
+	if !slices.Contains([]string{models.ReportByTask, models.ReportByTag, models.ReportByProject, models.ReportByDay, models.ReportByWeek}, options.GroupBy) {
+		return nil, ErrInvalidReportGroup
+	}
+	if !slices.Contains([]string{models.RoundUp, models.RoundDown, models.RoundNearest}, options.Rounding) {
+		return nil, ErrInvalidRounding
+	}
+	if !options.From.Before(options.To) {
+		return nil, ErrInvalidReportRange
+	}
+
+	entries, err := r.timeEntries.GetBetween(ctx, options.From, options.To)
+	if err != nil {
+		return nil, err
+	}
+
+	type rowKey struct {
+		label  string
+		taskID int
+	}
+	now := time.Now()
+	totals := make(map[string]map[rowKey]time.Duration)
+	taskTotals := make(map[int]time.Duration)
+	for _, entry := range entries {
+		duration := entry.Duration(now)
+		taskTotals[entry.TaskID] += duration
+
+		row := rowKey{label: entry.Task, taskID: entry.TaskID}
+		if options.GroupBy == models.ReportByTask {
+			row.label = entry.StartedAt.Local().Format(time.DateOnly)
+		}
+		for _, group := range reportGroups(entry, options.GroupBy) {
+			if totals[group] == nil {
+				totals[group] = make(map[rowKey]time.Duration)
+			}
+			totals[group][row] += duration
+		}
+	}
+
+	report := &models.TimeReport{TimeReportOptions: options}
+	for name, rows := range totals {
+		group := models.TimeReportGroup{Name: name}
+		for key, duration := range rows {
+			duration = roundDuration(duration, options.Round, options.Rounding)
+			group.Rows = append(group.Rows, models.TimeReportRow{Label: key.label, TaskID: key.taskID, Duration: duration})
+			group.Total += duration
+		}
+		slices.SortFunc(group.Rows, func(a, b models.TimeReportRow) int {
+			if a.Label != b.Label {
+				return strings.Compare(a.Label, b.Label)
+			}
+			return a.TaskID - b.TaskID
+		})
+		report.Groups = append(report.Groups, group)
+		report.Total += group.Total
+	}
+	slices.SortFunc(report.Groups, func(a, b models.TimeReportGroup) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	// A task with several tags shows up in several groups, but its time is only billed once.
+	if options.GroupBy == models.ReportByTag {
+		report.Total = 0
+		for _, duration := range taskTotals {
+			report.Total += roundDuration(duration, options.Round, options.Rounding)
+		}
+	}
+
+	return report, nil
+}
+
+// GetEstimateReport compares the estimates of the tasks completed in the report's period with the effort
+// they actually took, per tag and in total. When the actual effort is the tracked time, tasks without
+// any tracked time are skipped rather than counted as taking no time at all.
+func (r *ReportServiceImpl) GetEstimateReport(ctx context.Context, options models.EstimateReportOptions) (*models.EstimateReport, error) {
+	if options.Actual == "" {
+		options.Actual = models.ActualTracked
+	}
+	if options.Actual != models.ActualTracked && options.Actual != models.ActualElapsed {
+		return nil, ErrInvalidActual
+	}
+	if !options.From.Before(options.To) {
+		return nil, ErrInvalidReportRange
+	}
+
+	tasks, err := r.tasks.GetEstimatedCompleted(ctx, options.From, options.To)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.EstimateReport{EstimateReportOptions: options, Total: models.EstimateAccuracy{Name: "Total"}}
+	groups := make(map[string]*models.EstimateAccuracy)
+	for _, task := range tasks {
+		actual := task.TrackedTime
+		if options.Actual == models.ActualElapsed {
+			actual = task.CompletedAt.Sub(task.CreatedAt)
+		} else if actual == 0 {
+			report.Skipped++
+			continue
+		}
+
+		tags := task.Tags
+		if len(tags) == 0 {
+			tags = []string{untaggedGroup}
+		}
+		for _, tag := range tags {
+			if groups[tag] == nil {
+				groups[tag] = &models.EstimateAccuracy{Name: tag}
+			}
+			groups[tag].Add(task, actual)
+		}
+		report.Total.Add(task, actual)
+	}
+
+	for _, group := range groups {
+		report.Groups = append(report.Groups, *group)
+	}
+	slices.SortFunc(report.Groups, func(a, b models.EstimateAccuracy) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return report, nil
+}
+
+// reportGroups returns the names of the groups an entry belongs to.
+func reportGroups(entry models.TimeEntry, groupBy string) []string {
+	started := entry.StartedAt.Local()
+	switch groupBy {
+	case models.ReportByTag:
+		if len(entry.Tags) == 0 {
+			return []string{untaggedGroup}
+		}
+		return entry.Tags
+	case models.ReportByProject:
+		if entry.Project == "" {
+			return []string{noProjectGroup}
+		}
+		return []string{entry.Project}
+	case models.ReportByDay:
+		return []string{started.Format(time.DateOnly)}
+	case models.ReportByWeek:
+		monday := dates.StartOfDay(started).AddDate(0, 0, -(int(started.Weekday())+6)%7)
+		return []string{"Week of " + monday.Format(time.DateOnly)}
+	default:
+		return []string{fmt.Sprintf("%s (#%d)", entry.Task, entry.TaskID)}
+	}
+}
+
+// roundDuration rounds d to a multiple of unit in the given direction, leaving it unchanged when unit is zero.
+func roundDuration(d, unit time.Duration, rounding string) time.Duration {
+	if unit <= 0 {
+		return d
+	}
+
+	switch rounding {
+	case models.RoundDown:
+		return d.Truncate(unit)
+	case models.RoundNearest:
+		return d.Round(unit)
+	default:
+		return (d + unit - 1).Truncate(unit)
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/repositories"
+)
+
+func TestRoundDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		unit     time.Duration
+		rounding string
+		want     time.Duration
+	}{
+		{duration: 7 * time.Minute, unit: 15 * time.Minute, rounding: models.RoundUp, want: 15 * time.Minute},
+		{duration: 15 * time.Minute, unit: 15 * time.Minute, rounding: models.RoundUp, want: 15 * time.Minute},
+		{duration: 16 * time.Minute, unit: 15 * time.Minute, rounding: models.RoundUp, want: 30 * time.Minute},
+		{duration: 29 * time.Minute, unit: 15 * time.Minute, rounding: models.RoundDown, want: 15 * time.Minute},
+		{duration: 7 * time.Minute, unit: 15 * time.Minute, rounding: models.RoundDown, want: 0},
+		{duration: 22 * time.Minute, unit: 15 * time.Minute, rounding: models.RoundNearest, want: 15 * time.Minute},
+		{duration: 23 * time.Minute, unit: 15 * time.Minute, rounding: models.RoundNearest, want: 30 * time.Minute},
+		{duration: 7 * time.Minute, unit: 0, rounding: models.RoundUp, want: 7 * time.Minute},
+	}
+
+	for _, test := range tests {
+		if got := roundDuration(test.duration, test.unit, test.rounding); got != test.want {
+			t.Errorf("roundDuration(%v, %v, %s) = %v, want %v", test.duration, test.unit, test.rounding, got, test.want)
+		}
+	}
+}
+
+func TestTimeReport(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	tasks := newTestTaskService(db)
+	times := NewTimeService(repositories.NewTimeEntryRepository(db), repositories.NewPomodoroRepository(db), tasks)
+	reports := NewReportService(repositories.NewTimeEntryRepository(db), repositories.NewTaskRepository(db))
+
+	both := createTask(t, tasks, &models.Task{Title: "both", Tags: []string{"work", "client"}})
+	work := createTask(t, tasks, &models.Task{Title: "work", Tags: []string{"work"}})
+	untagged := createTask(t, tasks, &models.Task{Title: "untagged"})
+
+	now := time.Now()
+	for _, entry := range []struct {
+		taskID   int
+		duration time.Duration
+	}{
+		{taskID: both, duration: 10 * time.Minute},
+		{taskID: both, duration: 10 * time.Minute},
+		{taskID: work, duration: 40 * time.Minute},
+		{taskID: untagged, duration: 5 * time.Minute},
+	} {
+		if _, err := times.LogTime(ctx, entry.taskID, entry.duration, now.Add(-time.Hour), ""); err != nil {
+			t.Fatalf("LogTime returned error: %v", err)
+		}
+	}
+
+	report, err := reports.GetTimeReport(ctx, models.TimeReportOptions{
+		From:    now.Add(-24 * time.Hour),
+		To:      now,
+		GroupBy: models.ReportByTag,
+		Round:   15 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("GetTimeReport returned error: %v", err)
+	}
+
+	want := map[string]time.Duration{
+		"client":     30 * time.Minute,
+		"work":       75 * time.Minute,
+		"(untagged)": 15 * time.Minute,
+	}
+	if len(report.Groups) != len(want) {
+		t.Fatalf("report has %d groups, want %d", len(report.Groups), len(want))
+	}
+	for _, group := range report.Groups {
+		if group.Total != want[group.Name] {
+			t.Errorf("group %q totals %v, want %v", group.Name, group.Total, want[group.Name])
+		}
+	}
+	// The task tagged both work and client is billed once: 30m + 45m + 15m.
+	if report.Total != 90*time.Minute {
+		t.Errorf("report totals %v, want 1h30m", report.Total)
+	}
+}
+
+func TestTimeReportOptions(t *testing.T) {
+	reports := NewReportService(nil, nil)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		options models.TimeReportOptions
+		want    error
+	}{
+		{name: "group", options: models.TimeReportOptions{From: now.Add(-time.Hour), To: now, GroupBy: "month"}, want: ErrInvalidReportGroup},
+		{name: "rounding", options: models.TimeReportOptions{From: now.Add(-time.Hour), To: now, Rounding: "sideways"}, want: ErrInvalidRounding},
+		{name: "range", options: models.TimeReportOptions{From: now, To: now.Add(-time.Hour)}, want: ErrInvalidReportRange},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := reports.GetTimeReport(context.Background(), test.options); !errors.Is(err, test.want) {
+				t.Errorf("GetTimeReport returned error %v, want %v", err, test.want)
+			}
+		})
+	}
+}
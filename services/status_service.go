@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kkumar-gcc/todo/constants"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/repositories"
+)
+
+var (
+	ErrEmptyStatusName       = errors.New("status name cannot be empty")
+	ErrInvalidStatusColor    = errors.New("status color must be one of: " + strings.Join(constants.Colors, ", "))
+	ErrInvalidCategory       = errors.New("status category must be one of: " + strings.Join(models.Categories, ", "))
+	ErrStatusNotFound        = errors.New("status not found")
+	ErrStatusExists          = errors.New("a status with this name already exists")
+	ErrStatusCreationFailed  = errors.New("failed to create status")
+	ErrWorkflowUnavailable   = errors.New("failed to load the statuses, run the pending migrations with: todo migrate")
+	ErrWorkflowUpdateFailed  = errors.New("failed to update the workflow")
+	ErrTransitionNotFound    = errors.New("the workflow has no such transition")
+	ErrSelfTransitionInvalid = errors.New("a status cannot transition to itself")
+)
+
+type StatusService interface {
+	GetWorkflow(ctx context.Context) (*models.Workflow, error)
+	GetStatus(ctx context.Context, nameOrID string) (*models.Status, error)
+	CreateStatus(ctx context.Context, status *models.Status) error
+	AllowTransition(ctx context.Context, from, to string) error
+	DisallowTransition(ctx context.Context, from, to string) error
+}
+
+type StatusServiceImpl struct {
+	repository repositories.StatusRepository
+}
+
+// NewStatusService creates a new instance of StatusService
+func NewStatusService(repo repositories.StatusRepository) StatusService {
+	return &StatusServiceImpl{
+		repository: repo,
+	}
+}
+
+// GetWorkflow returns every status a task can be in, with the transitions allowed between them.
+func (r *StatusServiceImpl) GetWorkflow(ctx context.Context) (*models.Workflow, error) {
+	return loadWorkflow(ctx, r.repository)
+}
+
+// GetStatus looks a status up by its name, falling back to its numeric ID.
+func (r *StatusServiceImpl) GetStatus(ctx context.Context, nameOrID string) (*models.Status, error) {
+	workflow, err := r.GetWorkflow(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if status := workflow.StatusByName(nameOrID); status != nil {
+		return status, nil
+	}
+	if id, convErr := strconv.Atoi(strings.TrimSpace(nameOrID)); convErr == nil {
+		if status := workflow.Status(id); status != nil {
+			return status, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q, expected one of: %s", ErrStatusNotFound, nameOrID, strings.Join(workflow.Names(), ", "))
+}
+
+// CreateStatus adds a status to the workflow. Its name is normalized like a tag, and its label defaults to
+// the name. The transitions of a new status only lead out of it; use AllowTransition to lead into it.
+func (r *StatusServiceImpl) CreateStatus(ctx context.Context, status *models.Status) error {
+	status.Name = models.NormalizeTag(status.Name)
+	if status.Name == "" {
+		return ErrEmptyStatusName
+	}
+	if status.Label = strings.TrimSpace(status.Label); status.Label == "" {
+		status.Label = strings.ReplaceAll(status.Name, "-", " ")
+	}
+	if status.Color != "" && !slices.Contains(constants.Colors, status.Color) {
+		return ErrInvalidStatusColor
+	}
+	if !slices.Contains(models.Categories, status.Category) {
+		return ErrInvalidCategory
+	}
+
+	workflow, err := r.GetWorkflow(ctx)
+	if err != nil {
+		return err
+	}
+	if workflow.StatusByName(status.Name) != nil {
+		return ErrStatusExists
+	}
+	for _, to := range status.Transitions {
+		if workflow.Status(to) == nil {
+			return fmt.Errorf("%w: %d", ErrStatusNotFound, to)
+		}
+	}
+
+	if err := r.repository.Create(ctx, status); err != nil {
+		return ErrStatusCreationFailed
+	}
+
+	return nil
+}
+
+// AllowTransition lets tasks move from one status to another.
+func (r *StatusServiceImpl) AllowTransition(ctx context.Context, from, to string) error {
+	fromStatus, toStatus, err := r.transition(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	if err := r.repository.AddTransition(ctx, fromStatus.ID, toStatus.ID); err != nil {
+		return ErrWorkflowUpdateFailed
+	}
+	return nil
+}
+
+// DisallowTransition stops tasks from moving from one status to another. Tasks already moved are left as they are.
+func (r *StatusServiceImpl) DisallowTransition(ctx context.Context, from, to string) error {
+	fromStatus, toStatus, err := r.transition(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	err = r.repository.RemoveTransition(ctx, fromStatus.ID, toStatus.ID)
+	if errors.Is(err, repositories.ErrTransitionNotFound) {
+		return ErrTransitionNotFound
+	}
+	if err != nil {
+		return ErrWorkflowUpdateFailed
+	}
+	return nil
+}
+
+// transition looks up both ends of a transition.
+func (r *StatusServiceImpl) transition(ctx context.Context, from, to string) (*models.Status, *models.Status, error) {
+	fromStatus, err := r.GetStatus(ctx, from)
+	if err != nil {
+		return nil, nil, err
+	}
+	toStatus, err := r.GetStatus(ctx, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	if fromStatus.ID == toStatus.ID {
+		return nil, nil, ErrSelfTransitionInvalid
+	}
+	return fromStatus, toStatus, nil
+}
+
+// loadWorkflow reads the statuses and their transitions from the repository.
+func loadWorkflow(ctx context.Context, repository repositories.StatusRepository) (*models.Workflow, error) {
+	statuses, err := repository.GetAll(ctx)
+	if err != nil {
+		return nil, ErrWorkflowUnavailable
+	}
+	return &models.Workflow{Statuses: statuses}, nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kkumar-gcc/todo/constants"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/repositories"
+)
+
+// Built-in statuses seeded by the migrations, besides those in constants.
+const (
+	statusInReview  = 5
+	statusCancelled = 6
+)
+
+// setStatusWithReason moves a task to the given status, giving the reason for it.
+func setStatusWithReason(service TaskService, id, status int, reason string) error {
+	return service.UpdateTask(context.Background(), id, func(task *models.Task) (*models.Task, error) {
+		task.Status = status
+		task.StatusReason = reason
+		return task, nil
+	})
+}
+
+func TestStatusTransitions(t *testing.T) {
+	service := newTestTaskService(newTestDB(t))
+	id := createTask(t, service, &models.Task{Title: "task"})
+
+	if err := setStatus(service, id, statusInReview); !errors.Is(err, ErrTransitionDenied) {
+		t.Errorf("moving a pending task to review returned error %v, want %v", err, ErrTransitionDenied)
+	}
+	if err := setStatus(service, id, 99); !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("moving a task to an unknown status returned error %v, want %v", err, ErrInvalidStatus)
+	}
+	if err := setStatus(service, id, statusCancelled); !errors.Is(err, ErrReasonRequired) {
+		t.Errorf("cancelling without a reason returned error %v, want %v", err, ErrReasonRequired)
+	}
+
+	if err := setStatusWithReason(service, id, statusCancelled, " duplicate "); err != nil {
+		t.Fatalf("cancelling with a reason returned error: %v", err)
+	}
+	task := getTask(t, service, id)
+	if !task.IsDone() || task.CompletedAt == nil || task.StatusReason != "duplicate" {
+		t.Errorf("cancelled task is done = %v, completed at %v, reason %q", task.IsDone(), task.CompletedAt, task.StatusReason)
+	}
+
+	// Reopening the task drops the reason it was cancelled for.
+	if err := setStatus(service, id, constants.StatusPending); err != nil {
+		t.Fatalf("reopening the task returned error: %v", err)
+	}
+	if task := getTask(t, service, id); task.StatusReason != "" || task.CompletedAt != nil {
+		t.Errorf("reopened task has reason %q and completed at %v, want neither", task.StatusReason, task.CompletedAt)
+	}
+}
+
+func TestCustomStatus(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	tasks := newTestTaskService(db)
+	statuses := NewStatusService(repositories.NewStatusRepository(db))
+
+	waiting := &models.Status{Name: "Waiting On", Category: models.CategoryOpen, Transitions: []int{constants.StatusPending}}
+	if err := statuses.CreateStatus(ctx, waiting); err != nil {
+		t.Fatalf("CreateStatus returned error: %v", err)
+	}
+	if waiting.Name != "waiting-on" || waiting.Label != "waiting on" {
+		t.Errorf("status name %q and label %q, want waiting-on and waiting on", waiting.Name, waiting.Label)
+	}
+	if err := statuses.CreateStatus(ctx, &models.Status{Name: "waiting-on", Category: models.CategoryOpen}); !errors.Is(err, ErrStatusExists) {
+		t.Errorf("creating a status twice returned error %v, want %v", err, ErrStatusExists)
+	}
+
+	id := createTask(t, tasks, &models.Task{Title: "task"})
+	if err := setStatus(tasks, id, waiting.ID); !errors.Is(err, ErrTransitionDenied) {
+		t.Fatalf("moving to the new status before allowing it returned error %v, want %v", err, ErrTransitionDenied)
+	}
+	if err := statuses.AllowTransition(ctx, "pending", "waiting-on"); err != nil {
+		t.Fatalf("AllowTransition returned error: %v", err)
+	}
+	if err := setStatus(tasks, id, waiting.ID); err != nil {
+		t.Fatalf("moving to the new status returned error: %v", err)
+	}
+	if err := setStatus(tasks, id, constants.StatusPending); err != nil {
+		t.Errorf("moving back to pending returned error: %v", err)
+	}
+
+	if err := statuses.DisallowTransition(ctx, "pending", "waiting-on"); err != nil {
+		t.Fatalf("DisallowTransition returned error: %v", err)
+	}
+	if err := statuses.DisallowTransition(ctx, "pending", "waiting-on"); !errors.Is(err, ErrTransitionNotFound) {
+		t.Errorf("disallowing a missing transition returned error %v, want %v", err, ErrTransitionNotFound)
+	}
+}
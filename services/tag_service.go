@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/repositories"
+)
+
+var (
+	ErrEmptyTagName    = errors.New("tag name cannot be empty")
+	ErrTagNotFound     = errors.New("tag not found")
+	ErrTagExists       = errors.New("a tag with this name already exists, use tag:merge to combine them")
+	ErrTagUpdateFailed = errors.New("failed to update tag")
+)
+
+type TagService interface {
+	GetAllTags(ctx context.Context) ([]models.Tag, error)
+	RenameTag(ctx context.Context, name, newName string) error
+	MergeTags(ctx context.Context, names []string, into string) error
+	DeleteTag(ctx context.Context, name string) error
+}
+
+type TagServiceImpl struct {
+	repository repositories.TagRepository
+	operations repositories.OperationRepository
+}
+
+// NewTagService creates a new instance of TagService
+func NewTagService(repo repositories.TagRepository, operations repositories.OperationRepository) TagService {
+	return &TagServiceImpl{
+		repository: repo,
+		operations: operations,
+	}
+}
+
+// ParseTags splits a comma-separated list of tags, normalizing each and dropping empty and duplicate ones.
+func ParseTags(value string) []string {
+	return NormalizeTags(strings.Split(value, ","))
+}
+
+// NormalizeTags normalizes every tag, dropping empty and duplicate ones.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, tag := range tags {
+		tag = models.NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+func (r *TagServiceImpl) GetAllTags(ctx context.Context) ([]models.Tag, error) {
+	return r.repository.GetAll(ctx)
+}
+
+func (r *TagServiceImpl) RenameTag(ctx context.Context, name, newName string) error {
+	name, newName = models.NormalizeTag(name), models.NormalizeTag(newName)
+	if name == "" || newName == "" {
+		return ErrEmptyTagName
+	}
+	if name == newName {
+		return nil
+	}
+	if _, err := r.repository.GetByName(ctx, newName); err == nil {
+		return ErrTagExists
+	}
+
+	ctx, end := beginOperation(ctx, r.operations, fmt.Sprintf("rename tag %q to %q", name, newName))
+	defer end()
+
+	if err := r.repository.Rename(ctx, name, newName); err != nil {
+		if errors.Is(err, repositories.ErrTagNotFound) {
+			return ErrTagNotFound
+		}
+		return ErrTagUpdateFailed
+	}
+
+	return nil
+}
+
+func (r *TagServiceImpl) MergeTags(ctx context.Context, names []string, into string) error {
+	names, into = NormalizeTags(names), models.NormalizeTag(into)
+	if len(names) == 0 || into == "" {
+		return ErrEmptyTagName
+	}
+	for _, name := range names {
+		if _, err := r.repository.GetByName(ctx, name); err != nil {
+			return ErrTagNotFound
+		}
+	}
+
+	ctx, end := beginOperation(ctx, r.operations, fmt.Sprintf("merge tags %s into %q", strings.Join(names, ", "), into))
+	defer end()
+
+	if err := r.repository.Merge(ctx, names, into); err != nil {
+		return ErrTagUpdateFailed
+	}
+
+	return nil
+}
+
+func (r *TagServiceImpl) DeleteTag(ctx context.Context, name string) error {
+	name = models.NormalizeTag(name)
+	if name == "" {
+		return ErrEmptyTagName
+	}
+
+	ctx, end := beginOperation(ctx, r.operations, fmt.Sprintf("delete tag %q", name))
+	defer end()
+
+	if err := r.repository.Delete(ctx, name); err != nil {
+		if errors.Is(err, repositories.ErrTagNotFound) {
+			return ErrTagNotFound
+		}
+		return ErrTagUpdateFailed
+	}
+
+	return nil
+}
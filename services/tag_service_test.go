@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/kkumar-gcc/todo/models"
+)
+
+func TestParseTags(t *testing.T) {
+	got := ParseTags(" Work ,home,,HOME, big  work")
+	if want := []string{"work", "home", "big-work"}; !slices.Equal(got, want) {
+		t.Errorf("ParseTags = %q, want %q", got, want)
+	}
+}
+
+func TestTagFilters(t *testing.T) {
+	service := newTestTaskService(newTestDB(t))
+	createTask(t, service, &models.Task{Title: "work", Tags: []string{"work"}})
+	createTask(t, service, &models.Task{Title: "work at home", Tags: []string{"work", "home"}})
+	createTask(t, service, &models.Task{Title: "home", Tags: []string{"Home"}})
+	createTask(t, service, &models.Task{Title: "untagged"})
+
+	tests := []struct {
+		name   string
+		filter models.TaskFilter
+		want   []string
+	}{
+		{name: "any", filter: models.TaskFilter{AnyTags: []string{"work", "home"}}, want: []string{"home", "work", "work at home"}},
+		{name: "all", filter: models.TaskFilter{AllTags: []string{"work", "home"}}, want: []string{"work at home"}},
+		{name: "none", filter: models.TaskFilter{NoTags: []string{"work"}}, want: []string{"home", "untagged"}},
+		{name: "any and none", filter: models.TaskFilter{AnyTags: []string{"home"}, NoTags: []string{"work"}}, want: []string{"home"}},
+		{name: "unknown tag", filter: models.TaskFilter{AnyTags: []string{"garden"}}, want: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tasks, err := service.GetAllTasks(context.Background(), test.filter)
+			if err != nil {
+				t.Fatalf("GetAllTasks returned error: %v", err)
+			}
+
+			var titles []string
+			for _, task := range tasks {
+				titles = append(titles, task.Title)
+			}
+			slices.Sort(titles)
+			if !slices.Equal(titles, test.want) {
+				t.Errorf("GetAllTasks = %q, want %q", titles, test.want)
+			}
+		})
+	}
+}
@@ -1,60 +1,160 @@
 package services
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/kkumar-gcc/todo/constants"
 	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/recurrence"
 	"github.com/kkumar-gcc/todo/repositories"
 )
 
 var (
-	ErrInvalidID          = errors.New("id must be a positive integer")
-	ErrEmptyTitle         = errors.New("title cannot be empty")
-	ErrInvalidStatus      = errors.New("status must be a non-negative integer")
-	ErrInvalidPriority    = errors.New("priority must be a non-negative integer")
-	ErrTaskNotFound       = errors.New("task not found")
-	ErrTaskCreationFailed = errors.New("failed to create task")
-	ErrTaskUpdateFailed   = errors.New("failed to update task")
-	ErrTaskDeleteFailed   = errors.New("failed to delete task")
+	ErrInvalidID           = errors.New("id must be a positive integer")
+	ErrEmptyTitle          = errors.New("title cannot be empty")
+	ErrInvalidStatus       = errors.New("status is not part of the workflow")
+	ErrTransitionDenied    = errors.New("the workflow does not allow this status change")
+	ErrReasonRequired      = errors.New("a reason is required for this status")
+	ErrInvalidPriority     = errors.New("priority is not part of the priority scale")
+	ErrPriorityRemapFailed = errors.New("failed to migrate task priorities")
+	ErrSnoozeInPast        = errors.New("a task can only be snoozed until a moment in the future")
+	ErrSnoozeDone          = errors.New("a finished task cannot be snoozed")
+	ErrInvalidRecurrence   = errors.New("repeat rule is not valid")
+	ErrParentNotFound      = errors.New("parent task not found")
+	ErrTaskHasSubtasks     = errors.New("task has subtasks, choose whether to delete or re-parent them")
+	ErrOpenSubtasks        = errors.New("task has open subtasks")
+	ErrTaskBlocked         = errors.New("task is blocked by tasks that are still open")
+	ErrSelfDependency      = errors.New("a task cannot depend on itself")
+	ErrDependencyCycle     = errors.New("dependency would create a cycle")
+	ErrDependencyFailed    = errors.New("failed to update task dependencies")
+	ErrTaskNotFound        = errors.New("task not found")
+	ErrTaskCreationFailed  = errors.New("failed to create task")
+	ErrTaskUpdateFailed    = errors.New("failed to update task")
+	ErrTaskDeleteFailed    = errors.New("failed to delete task")
+	ErrTaskNotInTrash      = errors.New("task is not in the trash")
+	ErrTaskRestoreFailed   = errors.New("failed to restore task")
+	ErrEmptyTrashFailed    = errors.New("failed to empty the trash")
+	ErrTaskNotArchived     = errors.New("task is not archived")
+	ErrTaskArchiveFailed   = errors.New("failed to archive tasks")
+	ErrEmptySearchQuery    = errors.New("search query cannot be empty")
+	ErrInvalidSearchQuery  = errors.New("invalid search query, quote terms containing punctuation, e.g. \"work-stuff\"")
+	ErrSearchUnavailable   = errors.New("full-text search is unavailable, install todo with the sqlite_fts5 build tag and run: todo migrate")
 )
 
+// BulkTaskError reports which of the tasks given to CreateTasks is not valid.
+type BulkTaskError struct {
+	Index int // Position of the task in the list, starting at zero
+	Err   error
+}
+
+func (e *BulkTaskError) Error() string {
+	return fmt.Sprintf("task %d: %v", e.Index+1, e.Err)
+}
+
+func (e *BulkTaskError) Unwrap() error {
+	return e.Err
+}
+
+// SubtaskPolicy decides what happens to the subtasks of a deleted task.
+type SubtaskPolicy int
+
+const (
+	SubtasksReject   SubtaskPolicy = iota // Refuse to delete tasks that have subtasks
+	SubtasksCascade                       // Delete the subtasks as well
+	SubtasksReparent                      // Move the subtasks up to the deleted task's parent
+)
+
+// UpdateOption adjusts the checks UpdateTask performs before saving a change.
+type UpdateOption func(options *updateOptions)
+
+type updateOptions struct {
+	force bool
+}
+
+// Force skips the checks that would otherwise block starting or completing a task, such as open subtasks or blockers.
+func Force() UpdateOption {
+	return func(options *updateOptions) {
+		options.force = true
+	}
+}
+
 type TaskService interface {
-	CreateTask(ctx context.Context, title string, status, priority int, tags string) error
-	DeleteTask(ctx context.Context, id int) error
-	DeleteTasks(ctx context.Context, ids []int) error
-	GetAllTasks(ctx context.Context, status, priority int, sort string) ([]models.Task, error)
+	CreateTask(ctx context.Context, task *models.Task) error
+	CreateTaskTree(ctx context.Context, description string, nodes []models.TaskNode) error
+	CreateTasks(ctx context.Context, tasks []*models.Task) error
+	DeleteTask(ctx context.Context, id int, policy SubtaskPolicy) error
+	DeleteTasks(ctx context.Context, ids []int, policy SubtaskPolicy) error
+	RestoreTasks(ctx context.Context, ids []int) ([]int, error)
+	EmptyTrash(ctx context.Context, olderThan time.Duration) (int, error)
+	ArchiveTasks(ctx context.Context, ids []int) ([]int, error)
+	ArchiveCompletedBefore(ctx context.Context, completedBefore time.Time) ([]int, error)
+	AutoArchive(ctx context.Context, afterDays int) ([]int, error)
+	UnarchiveTasks(ctx context.Context, ids []int) ([]int, error)
+	SnoozeTask(ctx context.Context, id int, until *time.Time) error
+	RemapPriorities(ctx context.Context, from models.PriorityScale) ([]int, error)
+	GetAllTasks(ctx context.Context, filter models.TaskFilter) ([]models.Task, error)
+	SearchTasks(ctx context.Context, query string, filter models.TaskFilter) ([]models.SearchResult, error)
 	GetTaskByID(ctx context.Context, id int) (*models.Task, error)
-	UpdateTask(ctx context.Context, id int, updateFunc func(task *models.Task) (*models.Task, error)) error
+	GetNextTasks(ctx context.Context, limit int) ([]models.Task, error)
+	UpdateTask(ctx context.Context, id int, updateFunc func(task *models.Task) (*models.Task, error), opts ...UpdateOption) error
+	AddDependencies(ctx context.Context, id int, dependsOn []int) error
+	RemoveDependencies(ctx context.Context, id int, dependsOn []int) error
 }
 
 type TaskServiceImpl struct {
 	repository repositories.TaskRepository
+	operations repositories.OperationRepository
+	statuses   repositories.StatusRepository
+	priorities models.PriorityScale
+	urgency    models.UrgencyCoefficients
 }
 
 // NewTaskService creates a new instance of TaskService
-func NewTaskService(repo repositories.TaskRepository) TaskService {
+func NewTaskService(repo repositories.TaskRepository, operations repositories.OperationRepository, statuses repositories.StatusRepository, priorities models.PriorityScale, urgency models.UrgencyCoefficients) TaskService {
 	return &TaskServiceImpl{
 		repository: repo,
+		operations: operations,
+		statuses:   statuses,
+		priorities: priorities,
+		urgency:    urgency,
 	}
 }
 
-func (r *TaskServiceImpl) CreateTask(ctx context.Context, title string, status, priority int, tags string) error {
-	if title == "" {
-		return ErrEmptyTitle
-	}
-	if status < 0 {
-		return ErrInvalidStatus
+// beginOperation journals every change made under the returned context as one operation that can be
+// undone as a whole. The returned function must be called once the changes are done. If the journal
+// cannot be written the changes are still made, but cannot be undone.
+func (r *TaskServiceImpl) beginOperation(ctx context.Context, description string) (context.Context, func()) {
+	return beginOperation(ctx, r.operations, description)
+}
+
+// beginOperation starts an operation in the journal for any service changing tasks; see TaskServiceImpl.beginOperation.
+func beginOperation(ctx context.Context, operations repositories.OperationRepository, description string) (context.Context, func()) {
+	id, err := operations.Begin(ctx, description)
+	if err != nil {
+		return ctx, func() {}
 	}
-	if priority < 0 {
-		return ErrInvalidPriority
+
+	return repositories.WithOperation(ctx, id), func() {
+		_ = operations.End(ctx, id)
 	}
+}
 
-	task := &models.Task{
-		Title:    title,
-		Status:   status,
-		Priority: priority,
-		Tags:     tags,
+func (r *TaskServiceImpl) CreateTask(ctx context.Context, task *models.Task) error {
+	ctx, end := r.beginOperation(ctx, fmt.Sprintf("add task %q", task.Title))
+	defer end()
+
+	workflow, err := loadWorkflow(ctx, r.statuses)
+	if err != nil {
+		return err
+	}
+	if err := r.prepareTask(ctx, workflow, task); err != nil {
+		return err
 	}
 
 	if err := r.repository.Create(ctx, task); err != nil {
@@ -64,38 +164,367 @@ func (r *TaskServiceImpl) CreateTask(ctx context.Context, title string, status,
 	return nil
 }
 
-func (r *TaskServiceImpl) DeleteTask(ctx context.Context, id int) error {
-	if id <= 0 {
-		return ErrInvalidID
+// CreateTaskTree creates the tasks and their subtasks all at once: when any of them is not valid, none
+// are created. The creation is recorded as a single operation with the given description, so it is
+// undone as a whole.
+func (r *TaskServiceImpl) CreateTaskTree(ctx context.Context, description string, nodes []models.TaskNode) error {
+	ctx, end := r.beginOperation(ctx, description)
+	defer end()
+
+	workflow, err := loadWorkflow(ctx, r.statuses)
+	if err != nil {
+		return err
+	}
+	if err := r.prepareTree(ctx, workflow, nodes, false); err != nil {
+		return err
 	}
 
-	if err := r.repository.Delete(ctx, id); err != nil {
-		return ErrTaskDeleteFailed
+	if err := r.repository.CreateTree(ctx, nodes); err != nil {
+		return ErrTaskCreationFailed
+	}
+
+	return nil
+}
+
+// CreateTasks creates many tasks at once, such as when importing them from a file: when any of them is
+// not valid, none are created and a *BulkTaskError tells which one it was. The creation is recorded as a
+// single operation, so it is undone as a whole.
+func (r *TaskServiceImpl) CreateTasks(ctx context.Context, tasks []*models.Task) error {
+	ctx, end := r.beginOperation(ctx, fmt.Sprintf("add %d tasks", len(tasks)))
+	defer end()
+
+	workflow, err := loadWorkflow(ctx, r.statuses)
+	if err != nil {
+		return err
+	}
+	for i, task := range tasks {
+		if err := r.prepareTask(ctx, workflow, task); err != nil {
+			return &BulkTaskError{Index: i, Err: err}
+		}
+	}
+
+	if err := r.repository.CreateBulk(ctx, tasks); err != nil {
+		return ErrTaskCreationFailed
+	}
+
+	return nil
+}
+
+func (r *TaskServiceImpl) prepareTree(ctx context.Context, workflow *models.Workflow, nodes []models.TaskNode, nested bool) error {
+	for _, node := range nodes {
+		if nested {
+			// The parent is only known once it is created, so it must not be looked up beforehand.
+			node.Task.ParentID = nil
+		}
+		if err := r.prepareTask(ctx, workflow, node.Task); err != nil {
+			if node.Task.Title == "" {
+				return err
+			}
+			return fmt.Errorf("%q: %w", node.Task.Title, err)
+		}
+		if err := r.prepareTree(ctx, workflow, node.Subtasks, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prepareTask checks a task that is about to be created and fills in its defaults.
+func (r *TaskServiceImpl) prepareTask(ctx context.Context, workflow *models.Workflow, task *models.Task) error {
+	if task.Title == "" {
+		return ErrEmptyTitle
+	}
+	if task.Status == 0 {
+		task.Status = constants.StatusPending
+	}
+	status := workflow.Status(task.Status)
+	if status == nil {
+		return ErrInvalidStatus
+	}
+	if task.StatusReason = strings.TrimSpace(task.StatusReason); status.RequiresReason && task.StatusReason == "" {
+		return fmt.Errorf("%w: %s", ErrReasonRequired, status.Name)
+	}
+	if status.IsDone() && task.CompletedAt == nil {
+		now := time.Now()
+		task.CompletedAt = &now
+	}
+	if task.Priority == 0 {
+		task.Priority = r.priorities.DefaultRank()
+	}
+	if err := r.checkPriority(task.Priority); err != nil {
+		return err
+	}
+	if task.Recurrence != "" {
+		if _, err := recurrence.Parse(task.Recurrence); err != nil {
+			return ErrInvalidRecurrence
+		}
+	}
+	if task.ParentID != nil {
+		if _, err := r.repository.GetByID(ctx, *task.ParentID); err != nil {
+			return ErrParentNotFound
+		}
 	}
+	task.Tags = NormalizeTags(task.Tags)
 
 	return nil
 }
 
-func (r *TaskServiceImpl) DeleteTasks(ctx context.Context, ids []int) error {
+func (r *TaskServiceImpl) DeleteTask(ctx context.Context, id int, policy SubtaskPolicy) error {
+	if id <= 0 {
+		return ErrInvalidID
+	}
+
+	return r.DeleteTasks(ctx, []int{id}, policy)
+}
+
+// DeleteTasks moves tasks to the trash, from where they can be restored until the trash is emptied.
+func (r *TaskServiceImpl) DeleteTasks(ctx context.Context, ids []int, policy SubtaskPolicy) error {
 	if len(ids) <= 0 {
 		return ErrInvalidID
 	}
 
-	if err := r.repository.DeleteBulk(ctx, ids); err != nil {
+	ctx, end := r.beginOperation(ctx, fmt.Sprintf("delete tasks %v", ids))
+	defer end()
+
+	descendants, err := r.repository.GetDescendantIDs(ctx, ids)
+	if err != nil {
+		return ErrTaskDeleteFailed
+	}
+
+	remove := r.repository.DeleteBulk
+	if len(descendants) > 0 {
+		switch policy {
+		case SubtasksCascade:
+			ids = append(ids, descendants...)
+		case SubtasksReparent:
+			remove = r.repository.DeleteAndReparent
+		default:
+			return ErrTaskHasSubtasks
+		}
+	}
+
+	if err := remove(ctx, ids); err != nil {
+		if errors.Is(err, repositories.ErrTaskNotFound) {
+			return ErrTaskNotFound
+		}
 		return ErrTaskDeleteFailed
 	}
 
 	return nil
 }
 
-func (r *TaskServiceImpl) GetAllTasks(ctx context.Context, status, priority int, sort string) ([]models.Task, error) {
-	tasks, err := r.repository.GetAll(ctx, status, priority, sort)
+// RestoreTasks takes tasks out of the trash along with the subtasks deleted with them and any deleted
+// parents, returning the IDs of every restored task.
+func (r *TaskServiceImpl) RestoreTasks(ctx context.Context, ids []int) ([]int, error) {
+	if len(ids) == 0 || slices.ContainsFunc(ids, func(id int) bool { return id <= 0 }) {
+		return nil, ErrInvalidID
+	}
+
+	ctx, end := r.beginOperation(ctx, fmt.Sprintf("restore tasks %v", ids))
+	defer end()
+
+	restored, err := r.repository.Restore(ctx, ids)
+	if errors.Is(err, repositories.ErrTaskNotFound) {
+		return nil, ErrTaskNotInTrash
+	}
+	if err != nil {
+		return nil, ErrTaskRestoreFailed
+	}
+
+	return restored, nil
+}
+
+// EmptyTrash permanently deletes the tasks that have been in the trash for longer than olderThan, or
+// every task in the trash when olderThan is zero. It returns how many tasks were deleted.
+func (r *TaskServiceImpl) EmptyTrash(ctx context.Context, olderThan time.Duration) (int, error) {
+	ctx, end := r.beginOperation(ctx, "empty trash")
+	defer end()
+
+	var deletedBefore *time.Time
+	if olderThan > 0 {
+		cutoff := time.Now().Add(-olderThan)
+		deletedBefore = &cutoff
+	}
+
+	purged, err := r.repository.Purge(ctx, deletedBefore)
+	if err != nil {
+		return 0, ErrEmptyTrashFailed
+	}
+
+	return purged, nil
+}
+
+// ArchiveTasks archives the given tasks that are completed and returns the IDs of those it archived.
+// Archived tasks are left out of listings, but can still be searched and count towards statistics.
+func (r *TaskServiceImpl) ArchiveTasks(ctx context.Context, ids []int) ([]int, error) {
+	if len(ids) == 0 || slices.ContainsFunc(ids, func(id int) bool { return id <= 0 }) {
+		return nil, ErrInvalidID
+	}
+
+	ctx, end := r.beginOperation(ctx, fmt.Sprintf("archive tasks %v", ids))
+	defer end()
+
+	archived, err := r.repository.Archive(ctx, ids)
+	if err != nil {
+		return nil, ErrTaskArchiveFailed
+	}
+
+	return archived, nil
+}
+
+// ArchiveCompletedBefore archives every task completed before the given moment and returns their IDs.
+func (r *TaskServiceImpl) ArchiveCompletedBefore(ctx context.Context, completedBefore time.Time) ([]int, error) {
+	ctx, end := r.beginOperation(ctx, "archive tasks completed before "+completedBefore.Format(time.DateOnly))
+	defer end()
+
+	archived, err := r.repository.ArchiveCompleted(ctx, completedBefore)
+	if err != nil {
+		return nil, ErrTaskArchiveFailed
+	}
+
+	return archived, nil
+}
+
+// AutoArchive applies the archive policy, archiving tasks completed more than afterDays days ago.
+// It does nothing when afterDays is zero.
+func (r *TaskServiceImpl) AutoArchive(ctx context.Context, afterDays int) ([]int, error) {
+	if afterDays <= 0 {
+		return nil, nil
+	}
+
+	ctx, end := r.beginOperation(ctx, "apply the archive policy")
+	defer end()
+
+	archived, err := r.repository.ArchiveCompleted(ctx, time.Now().AddDate(0, 0, -afterDays))
+	if err != nil {
+		return nil, ErrTaskArchiveFailed
+	}
+
+	return archived, nil
+}
+
+// SnoozeTask hides an open task from listings until the given moment, or wakes it up when until is nil.
+func (r *TaskServiceImpl) SnoozeTask(ctx context.Context, id int, until *time.Time) error {
+	if until != nil && !until.After(time.Now()) {
+		return ErrSnoozeInPast
+	}
+
+	if until != nil {
+		task, err := r.GetTaskByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if task.IsDone() {
+			return ErrSnoozeDone
+		}
+	}
+
+	return r.UpdateTask(ctx, id, func(task *models.Task) (*models.Task, error) {
+		task.WaitUntil = until
+		return task, nil
+	})
+}
+
+// RemapPriorities moves the priority of every task from the scale from onto the configured scale, and
+// returns the IDs of the tasks it changed. Levels with the same name in both scales are kept, the others
+// are moved to the same relative height, so low, medium and high become the lowest, middle and highest level.
+func (r *TaskServiceImpl) RemapPriorities(ctx context.Context, from models.PriorityScale) ([]int, error) {
+	ctx, end := r.beginOperation(ctx, "migrate task priorities")
+	defer end()
+
+	remapped, err := r.repository.RemapPriorities(ctx, r.priorities.RemapFrom(from))
+	if err != nil {
+		return nil, ErrPriorityRemapFailed
+	}
+
+	return remapped, nil
+}
+
+// UnarchiveTasks returns archived tasks to the active list and returns their IDs.
+func (r *TaskServiceImpl) UnarchiveTasks(ctx context.Context, ids []int) ([]int, error) {
+	if len(ids) == 0 || slices.ContainsFunc(ids, func(id int) bool { return id <= 0 }) {
+		return nil, ErrInvalidID
+	}
+
+	ctx, end := r.beginOperation(ctx, fmt.Sprintf("unarchive tasks %v", ids))
+	defer end()
+
+	unarchived, err := r.repository.Unarchive(ctx, ids)
+	if err != nil {
+		return nil, ErrTaskUpdateFailed
+	}
+	if len(unarchived) == 0 {
+		return nil, ErrTaskNotArchived
+	}
+
+	return unarchived, nil
+}
+
+func (r *TaskServiceImpl) GetAllTasks(ctx context.Context, filter models.TaskFilter) ([]models.Task, error) {
+	tasks, err := r.repository.GetAll(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
+
+	r.scoreUrgency(tasks, time.Now())
+	if filter.Sort == "urgency" {
+		sortByUrgency(tasks)
+	}
 	return tasks, nil
 }
 
+// GetNextTasks returns the open tasks that can be worked on now, the most urgent first. Tasks blocked
+// by open dependencies are left out. A limit of zero returns every such task.
+func (r *TaskServiceImpl) GetNextTasks(ctx context.Context, limit int) ([]models.Task, error) {
+	tasks, err := r.repository.GetAll(ctx, models.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	actionable := slices.DeleteFunc(tasks, func(task models.Task) bool {
+		return task.IsDone() || task.IsBlocked()
+	})
+	r.scoreUrgency(actionable, time.Now())
+	sortByUrgency(actionable)
+
+	if limit > 0 && len(actionable) > limit {
+		actionable = actionable[:limit]
+	}
+	return actionable, nil
+}
+
+// scoreUrgency sets the urgency of each task.
+func (r *TaskServiceImpl) scoreUrgency(tasks []models.Task, now time.Time) {
+	for i := range tasks {
+		tasks[i].Urgency = r.urgency.Score(&tasks[i], r.priorities, now)
+	}
+}
+
+// sortByUrgency orders tasks from the most urgent down, keeping the order of equally urgent tasks.
+func sortByUrgency(tasks []models.Task) {
+	slices.SortStableFunc(tasks, func(a, b models.Task) int {
+		return cmp.Compare(b.Urgency, a.Urgency)
+	})
+}
+
+// SearchTasks runs a full-text query against task titles, tags and notes. The query supports the FTS5
+// syntax: "exact phrases", prefix*, AND, OR, NOT and column filters such as title:report.
+func (r *TaskServiceImpl) SearchTasks(ctx context.Context, query string, filter models.TaskFilter) ([]models.SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	results, err := r.repository.Search(ctx, query, filter)
+	switch {
+	case errors.Is(err, repositories.ErrInvalidSearchQuery):
+		return nil, ErrInvalidSearchQuery
+	case errors.Is(err, repositories.ErrSearchIndexMissing):
+		return nil, ErrSearchUnavailable
+	}
+	return results, err
+}
+
 func (r *TaskServiceImpl) GetTaskByID(ctx context.Context, id int) (*models.Task, error) {
 	if id <= 0 {
 		return nil, ErrInvalidID
@@ -106,17 +535,250 @@ func (r *TaskServiceImpl) GetTaskByID(ctx context.Context, id int) (*models.Task
 		return nil, ErrTaskNotFound
 	}
 
+	task.Urgency = r.urgency.Score(task, r.priorities, time.Now())
 	return task, nil
 }
 
-func (r *TaskServiceImpl) UpdateTask(ctx context.Context, id int, updateFunc func(task *models.Task) (*models.Task, error)) error {
+func (r *TaskServiceImpl) UpdateTask(ctx context.Context, id int, updateFunc func(task *models.Task) (*models.Task, error), opts ...UpdateOption) error {
 	if id <= 0 {
 		return ErrInvalidID
 	}
 
-	if err := r.repository.Update(ctx, id, updateFunc); err != nil {
+	ctx, end := r.beginOperation(ctx, fmt.Sprintf("update task %d", id))
+	defer end()
+
+	var options updateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	workflow, err := loadWorkflow(ctx, r.statuses)
+	if err != nil {
+		return err
+	}
+
+	var validationErr error
+	var next *models.Task
+	err = r.repository.Update(ctx, id, func(task *models.Task) (*models.Task, *models.Task, error) {
+		previousStatus, previousReason, previousPriority := task.Status, task.StatusReason, task.Priority
+		updatedTask, err := updateFunc(task)
+		if err != nil {
+			return nil, nil, err
+		}
+		updatedTask.Tags = NormalizeTags(updatedTask.Tags)
+		// Updating a task that came back from being snoozed clears the ended snooze.
+		if !updatedTask.IsWaiting(time.Now()) {
+			updatedTask.WaitUntil = nil
+		}
+		updatedTask.ResurfacedAt = nil
+
+		if updatedTask.Recurrence != "" {
+			if _, err := recurrence.Parse(updatedTask.Recurrence); err != nil {
+				validationErr = ErrInvalidRecurrence
+				return nil, nil, validationErr
+			}
+		}
+
+		if updatedTask.Priority != previousPriority {
+			if validationErr = r.checkPriority(updatedTask.Priority); validationErr != nil {
+				return nil, nil, validationErr
+			}
+		}
+
+		if validationErr = checkTransition(workflow, previousStatus, previousReason, updatedTask); validationErr != nil {
+			return nil, nil, validationErr
+		}
+
+		starting := updatedTask.Status != previousStatus && workflow.Status(updatedTask.Status).Category != models.CategoryOpen
+		if starting && updatedTask.IsBlocked() && !options.force {
+			validationErr = ErrTaskBlocked
+			return nil, nil, validationErr
+		}
+
+		now := time.Now()
+		if !isDone(workflow, updatedTask.Status) {
+			updatedTask.CompletedAt = nil
+			updatedTask.ArchivedAt = nil
+		} else if !isDone(workflow, previousStatus) {
+			if !options.force {
+				open, err := r.repository.CountOpenSubtasks(ctx, id)
+				if err != nil {
+					return nil, nil, err
+				}
+				if open > 0 {
+					validationErr = ErrOpenSubtasks
+					return nil, nil, validationErr
+				}
+			}
+
+			updatedTask.CompletedAt = &now
+			updatedTask.WaitUntil = nil
+			if updatedTask.Recurrence != "" {
+				next = nextOccurrence(updatedTask, now)
+				updatedTask.Recurrence = ""
+			}
+		}
+
+		// The next occurrence is created along with the completion, so that neither is saved without the other.
+		return updatedTask, next, nil
+	})
+	if validationErr != nil {
+		return validationErr
+	}
+	if err != nil {
 		return ErrTaskUpdateFailed
 	}
 
 	return nil
 }
+
+// checkPriority rejects priorities outside the configured scale.
+func (r *TaskServiceImpl) checkPriority(priority int) error {
+	if r.priorities.Level(priority) == nil {
+		return fmt.Errorf("%w, expected one of: %s", ErrInvalidPriority, strings.Join(r.priorities.Names(), ", "))
+	}
+	return nil
+}
+
+func (r *TaskServiceImpl) AddDependencies(ctx context.Context, id int, dependsOn []int) error {
+	if id <= 0 || len(dependsOn) == 0 {
+		return ErrInvalidID
+	}
+
+	ctx, end := r.beginOperation(ctx, fmt.Sprintf("make task %d depend on %v", id, dependsOn))
+	defer end()
+
+	if _, err := r.repository.GetByID(ctx, id); err != nil {
+		return ErrTaskNotFound
+	}
+
+	graph, err := r.repository.GetDependencies(ctx)
+	if err != nil {
+		return ErrDependencyFailed
+	}
+
+	for _, dependency := range dependsOn {
+		if dependency == id {
+			return ErrSelfDependency
+		}
+		if _, err := r.repository.GetByID(ctx, dependency); err != nil {
+			return fmt.Errorf("%w: %d", ErrTaskNotFound, dependency)
+		}
+		if reachable(graph, dependency, id) {
+			return fmt.Errorf("%w: task %d already depends on task %d", ErrDependencyCycle, dependency, id)
+		}
+		graph[id] = append(graph[id], dependency)
+	}
+
+	if err := r.repository.AddDependencies(ctx, id, dependsOn); err != nil {
+		return ErrDependencyFailed
+	}
+
+	return nil
+}
+
+func (r *TaskServiceImpl) RemoveDependencies(ctx context.Context, id int, dependsOn []int) error {
+	if id <= 0 || len(dependsOn) == 0 {
+		return ErrInvalidID
+	}
+
+	ctx, end := r.beginOperation(ctx, fmt.Sprintf("remove dependencies %v from task %d", dependsOn, id))
+	defer end()
+
+	if err := r.repository.RemoveDependencies(ctx, id, dependsOn); err != nil {
+		if errors.Is(err, repositories.ErrDependencyNotFound) {
+			return err
+		}
+		return ErrDependencyFailed
+	}
+
+	return nil
+}
+
+// checkTransition validates a task's change of status against the workflow. A reason given for the
+// previous status is dropped when the status changes, unless a new one was given.
+func checkTransition(workflow *models.Workflow, previous int, previousReason string, task *models.Task) error {
+	task.StatusReason = strings.TrimSpace(task.StatusReason)
+	status := workflow.Status(task.Status)
+	if task.Status != previous {
+		if status == nil {
+			return ErrInvalidStatus
+		}
+		if !workflow.CanTransition(previous, task.Status) {
+			from := workflow.Status(previous)
+			var allowed []string
+			for _, target := range workflow.Targets(previous) {
+				allowed = append(allowed, target.Name)
+			}
+			if len(allowed) == 0 {
+				return fmt.Errorf("%w from %s to %s, it cannot move to any other status", ErrTransitionDenied, from.Name, status.Name)
+			}
+			return fmt.Errorf("%w from %s to %s, it can move to: %s", ErrTransitionDenied, from.Name, status.Name, strings.Join(allowed, ", "))
+		}
+		if task.StatusReason == previousReason {
+			task.StatusReason = ""
+		}
+	}
+
+	if status != nil && status.RequiresReason && task.StatusReason == "" {
+		return fmt.Errorf("%w: %s", ErrReasonRequired, status.Name)
+	}
+	return nil
+}
+
+// isDone reports whether the status with the given ID is in the done category.
+func isDone(workflow *models.Workflow, id int) bool {
+	status := workflow.Status(id)
+	return status != nil && status.IsDone()
+}
+
+// reachable reports whether target can be reached from start by following dependency edges.
+func reachable(graph map[int][]int, start, target int) bool {
+	visited := make(map[int]bool)
+	stack := []int{start}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == target {
+			return true
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		stack = append(stack, graph[id]...)
+	}
+	return false
+}
+
+// nextOccurrence builds the next instance of a repeating task that was just completed,
+// or returns nil when its series has ended. Occurrences that are already in the past are skipped.
+func nextOccurrence(task *models.Task, now time.Time) *models.Task {
+	rule, err := recurrence.Parse(task.Recurrence)
+	if err != nil {
+		return nil
+	}
+
+	base := now
+	if task.DueAt != nil {
+		base = *task.DueAt
+	}
+
+	due, rest, ok := rule.NextAfter(base, now)
+	if !ok {
+		return nil
+	}
+
+	return &models.Task{
+		Title:      task.Title,
+		Notes:      task.Notes,
+		Status:     constants.StatusPending,
+		Priority:   task.Priority,
+		Tags:       slices.Clone(task.Tags),
+		DueAt:      &due,
+		Recurrence: rest.String(),
+		ParentID:   task.ParentID,
+		ProjectID:  task.ProjectID,
+		Estimate:   task.Estimate,
+	}
+}
@@ -0,0 +1,616 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kkumar-gcc/todo/constants"
+	"github.com/kkumar-gcc/todo/database"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/repositories"
+)
+
+// newTestDB opens a migrated database in a temporary directory.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := database.Open(filepath.Join(t.TempDir(), "todo.db"))
+	if err != nil {
+		t.Fatalf("failed to open the database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := database.NewMigrator(db).Migrate(context.Background()); err != nil {
+		t.Fatalf("failed to migrate the database: %v", err)
+	}
+	return db
+}
+
+// newTestTaskService returns a TaskService on the database with the default priorities and urgency.
+func newTestTaskService(db *sql.DB) TaskService {
+	return NewTaskService(repositories.NewTaskRepository(db), repositories.NewOperationRepository(db), repositories.NewStatusRepository(db),
+		models.DefaultPriorityScale(), models.DefaultUrgencyCoefficients())
+}
+
+// createTask creates the task and returns its ID.
+func createTask(t *testing.T, service TaskService, task *models.Task) int {
+	t.Helper()
+
+	if err := service.CreateTask(context.Background(), task); err != nil {
+		t.Fatalf("CreateTask(%q) returned error: %v", task.Title, err)
+	}
+	return task.ID
+}
+
+// getTask returns the task with the given ID.
+func getTask(t *testing.T, service TaskService, id int) *models.Task {
+	t.Helper()
+
+	task, err := service.GetTaskByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetTaskByID(%d) returned error: %v", id, err)
+	}
+	return task
+}
+
+// setStatus moves a task to the given status.
+func setStatus(service TaskService, id, status int, opts ...UpdateOption) error {
+	return service.UpdateTask(context.Background(), id, func(task *models.Task) (*models.Task, error) {
+		task.Status = status
+		return task, nil
+	}, opts...)
+}
+
+func TestSubtaskRollup(t *testing.T) {
+	service := newTestTaskService(newTestDB(t))
+	parent := createTask(t, service, &models.Task{Title: "parent"})
+	first := createTask(t, service, &models.Task{Title: "first", ParentID: &parent})
+	createTask(t, service, &models.Task{Title: "second", ParentID: &parent})
+
+	if err := setStatus(service, first, constants.StatusCompleted); err != nil {
+		t.Fatalf("completing a subtask returned error: %v", err)
+	}
+
+	task := getTask(t, service, parent)
+	if task.SubtaskCount != 2 || task.CompletedSubtasks != 1 {
+		t.Errorf("parent has %d/%d subtasks done, want 1/2", task.CompletedSubtasks, task.SubtaskCount)
+	}
+}
+
+func TestCreateSubtaskOfMissingParent(t *testing.T) {
+	service := newTestTaskService(newTestDB(t))
+	missing := 42
+
+	err := service.CreateTask(context.Background(), &models.Task{Title: "orphan", ParentID: &missing})
+	if !errors.Is(err, ErrParentNotFound) {
+		t.Errorf("CreateTask returned error %v, want %v", err, ErrParentNotFound)
+	}
+}
+
+func TestCompleteTaskWithOpenSubtasks(t *testing.T) {
+	service := newTestTaskService(newTestDB(t))
+	parent := createTask(t, service, &models.Task{Title: "parent"})
+	createTask(t, service, &models.Task{Title: "subtask", ParentID: &parent})
+
+	if err := setStatus(service, parent, constants.StatusCompleted); !errors.Is(err, ErrOpenSubtasks) {
+		t.Fatalf("completing the parent returned error %v, want %v", err, ErrOpenSubtasks)
+	}
+	if task := getTask(t, service, parent); task.IsDone() {
+		t.Fatal("parent was completed despite its open subtask")
+	}
+
+	if err := setStatus(service, parent, constants.StatusCompleted, Force()); err != nil {
+		t.Fatalf("forcing the parent to complete returned error: %v", err)
+	}
+	if task := getTask(t, service, parent); !task.IsDone() {
+		t.Error("parent was not completed when forced")
+	}
+}
+
+func TestDeleteTaskWithSubtasks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reject", func(t *testing.T) {
+		service := newTestTaskService(newTestDB(t))
+		parent := createTask(t, service, &models.Task{Title: "parent"})
+		createTask(t, service, &models.Task{Title: "subtask", ParentID: &parent})
+
+		if err := service.DeleteTask(ctx, parent, SubtasksReject); !errors.Is(err, ErrTaskHasSubtasks) {
+			t.Fatalf("DeleteTask returned error %v, want %v", err, ErrTaskHasSubtasks)
+		}
+		getTask(t, service, parent)
+	})
+
+	t.Run("cascade", func(t *testing.T) {
+		service := newTestTaskService(newTestDB(t))
+		parent := createTask(t, service, &models.Task{Title: "parent"})
+		subtask := createTask(t, service, &models.Task{Title: "subtask", ParentID: &parent})
+
+		if err := service.DeleteTask(ctx, parent, SubtasksCascade); err != nil {
+			t.Fatalf("DeleteTask returned error: %v", err)
+		}
+		if _, err := service.GetTaskByID(ctx, subtask); !errors.Is(err, ErrTaskNotFound) {
+			t.Errorf("subtask lookup returned error %v, want %v", err, ErrTaskNotFound)
+		}
+	})
+
+	t.Run("reparent", func(t *testing.T) {
+		service := newTestTaskService(newTestDB(t))
+		root := createTask(t, service, &models.Task{Title: "root"})
+		parent := createTask(t, service, &models.Task{Title: "parent", ParentID: &root})
+		subtask := createTask(t, service, &models.Task{Title: "subtask", ParentID: &parent})
+
+		if err := service.DeleteTask(ctx, parent, SubtasksReparent); err != nil {
+			t.Fatalf("DeleteTask returned error: %v", err)
+		}
+		if task := getTask(t, service, subtask); task.ParentID == nil || *task.ParentID != root {
+			t.Errorf("subtask parent = %v, want %d", task.ParentID, root)
+		}
+	})
+}
+
+func TestAddDependencies(t *testing.T) {
+	ctx := context.Background()
+	service := newTestTaskService(newTestDB(t))
+	first := createTask(t, service, &models.Task{Title: "first"})
+	second := createTask(t, service, &models.Task{Title: "second"})
+	third := createTask(t, service, &models.Task{Title: "third"})
+
+	// third depends on second, which depends on first.
+	if err := service.AddDependencies(ctx, second, []int{first}); err != nil {
+		t.Fatalf("AddDependencies returned error: %v", err)
+	}
+	if err := service.AddDependencies(ctx, third, []int{second}); err != nil {
+		t.Fatalf("AddDependencies returned error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		id        int
+		dependsOn []int
+		want      error
+	}{
+		{name: "itself", id: first, dependsOn: []int{first}, want: ErrSelfDependency},
+		{name: "direct cycle", id: first, dependsOn: []int{second}, want: ErrDependencyCycle},
+		{name: "indirect cycle", id: first, dependsOn: []int{third}, want: ErrDependencyCycle},
+		{name: "missing dependency", id: first, dependsOn: []int{99}, want: ErrTaskNotFound},
+		{name: "missing task", id: 99, dependsOn: []int{first}, want: ErrTaskNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := service.AddDependencies(ctx, test.id, test.dependsOn); !errors.Is(err, test.want) {
+				t.Errorf("AddDependencies(%d, %v) returned error %v, want %v", test.id, test.dependsOn, err, test.want)
+			}
+		})
+	}
+
+	if task := getTask(t, service, first); task.IsBlocked() {
+		t.Errorf("first is blocked by %v after the rejected dependencies", task.BlockedBy)
+	}
+}
+
+func TestBlockedTask(t *testing.T) {
+	ctx := context.Background()
+	service := newTestTaskService(newTestDB(t))
+	blocker := createTask(t, service, &models.Task{Title: "blocker"})
+	blocked := createTask(t, service, &models.Task{Title: "blocked"})
+	if err := service.AddDependencies(ctx, blocked, []int{blocker}); err != nil {
+		t.Fatalf("AddDependencies returned error: %v", err)
+	}
+
+	if task := getTask(t, service, blocked); len(task.BlockedBy) != 1 || task.BlockedBy[0] != blocker {
+		t.Fatalf("blocked task is blocked by %v, want [%d]", task.BlockedBy, blocker)
+	}
+	if err := setStatus(service, blocked, constants.StatusInProgress); !errors.Is(err, ErrTaskBlocked) {
+		t.Fatalf("starting the blocked task returned error %v, want %v", err, ErrTaskBlocked)
+	}
+
+	if err := setStatus(service, blocker, constants.StatusCompleted); err != nil {
+		t.Fatalf("completing the blocker returned error: %v", err)
+	}
+	if err := setStatus(service, blocked, constants.StatusInProgress); err != nil {
+		t.Errorf("starting the task once its blocker is done returned error: %v", err)
+	}
+}
+
+// titles returns the titles of the tasks the filter lists.
+func titles(t *testing.T, service TaskService, filter models.TaskFilter) []string {
+	t.Helper()
+
+	tasks, err := service.GetAllTasks(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("GetAllTasks returned error: %v", err)
+	}
+
+	var titles []string
+	for _, task := range tasks {
+		titles = append(titles, task.Title)
+	}
+	slices.Sort(titles)
+	return titles
+}
+
+func TestTrashAndRestore(t *testing.T) {
+	ctx := context.Background()
+	service := newTestTaskService(newTestDB(t))
+	parent := createTask(t, service, &models.Task{Title: "parent"})
+	subtask := createTask(t, service, &models.Task{Title: "subtask", ParentID: &parent})
+	createTask(t, service, &models.Task{Title: "kept"})
+
+	if err := service.DeleteTask(ctx, parent, SubtasksCascade); err != nil {
+		t.Fatalf("DeleteTask returned error: %v", err)
+	}
+	if got, want := titles(t, service, models.TaskFilter{}), []string{"kept"}; !slices.Equal(got, want) {
+		t.Errorf("active tasks = %q, want %q", got, want)
+	}
+	if got, want := titles(t, service, models.TaskFilter{Deleted: true}), []string{"parent", "subtask"}; !slices.Equal(got, want) {
+		t.Errorf("trashed tasks = %q, want %q", got, want)
+	}
+
+	// Restoring the subtask brings its deleted parent back with it.
+	restored, err := service.RestoreTasks(ctx, []int{subtask})
+	if err != nil {
+		t.Fatalf("RestoreTasks returned error: %v", err)
+	}
+	slices.Sort(restored)
+	if want := []int{parent, subtask}; !slices.Equal(restored, want) {
+		t.Errorf("RestoreTasks restored %v, want %v", restored, want)
+	}
+
+	if _, err := service.RestoreTasks(ctx, []int{parent}); !errors.Is(err, ErrTaskNotInTrash) {
+		t.Errorf("restoring an active task returned error %v, want %v", err, ErrTaskNotInTrash)
+	}
+}
+
+func TestEmptyTrash(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	service := newTestTaskService(db)
+	old := createTask(t, service, &models.Task{Title: "old"})
+	recent := createTask(t, service, &models.Task{Title: "recent"})
+	if err := service.DeleteTasks(ctx, []int{old, recent}, SubtasksReject); err != nil {
+		t.Fatalf("DeleteTasks returned error: %v", err)
+	}
+	if _, err := db.Exec("UPDATE tasks SET deleted_at = ? WHERE id = ?", time.Now().AddDate(0, 0, -40).UTC(), old); err != nil {
+		t.Fatalf("failed to backdate the deletion: %v", err)
+	}
+
+	purged, err := service.EmptyTrash(ctx, 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("EmptyTrash returned error: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("EmptyTrash(30 days) purged %d tasks, want 1", purged)
+	}
+	if got, want := titles(t, service, models.TaskFilter{Deleted: true}), []string{"recent"}; !slices.Equal(got, want) {
+		t.Errorf("trashed tasks = %q, want %q", got, want)
+	}
+
+	if purged, err = service.EmptyTrash(ctx, 0); err != nil || purged != 1 {
+		t.Errorf("EmptyTrash(0) purged %d tasks with error %v, want 1", purged, err)
+	}
+	if _, err := service.RestoreTasks(ctx, []int{recent}); !errors.Is(err, ErrTaskNotInTrash) {
+		t.Errorf("restoring a purged task returned error %v, want %v", err, ErrTaskNotInTrash)
+	}
+}
+
+// completeTask completes a task as if it had been completed the given number of days ago.
+func completeTask(t *testing.T, db *sql.DB, service TaskService, id, daysAgo int) {
+	t.Helper()
+
+	if err := setStatus(service, id, constants.StatusCompleted); err != nil {
+		t.Fatalf("completing task %d returned error: %v", id, err)
+	}
+	if _, err := db.Exec("UPDATE tasks SET completed_at = ? WHERE id = ?", time.Now().AddDate(0, 0, -daysAgo).UTC(), id); err != nil {
+		t.Fatalf("failed to backdate the completion: %v", err)
+	}
+}
+
+func TestAutoArchive(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	service := newTestTaskService(db)
+	undo := NewUndoService(repositories.NewOperationRepository(db))
+	old := createTask(t, service, &models.Task{Title: "old"})
+	recent := createTask(t, service, &models.Task{Title: "recent"})
+	createTask(t, service, &models.Task{Title: "open"})
+	completeTask(t, db, service, old, 40)
+	completeTask(t, db, service, recent, 5)
+
+	if archived, err := service.AutoArchive(ctx, 0); err != nil || len(archived) != 0 {
+		t.Fatalf("AutoArchive(0) archived %v with error %v, want nothing", archived, err)
+	}
+
+	archived, err := service.AutoArchive(ctx, 30)
+	if err != nil {
+		t.Fatalf("AutoArchive returned error: %v", err)
+	}
+	if want := []int{old}; !slices.Equal(archived, want) {
+		t.Errorf("AutoArchive(30) archived %v, want %v", archived, want)
+	}
+	if got, want := titles(t, service, models.TaskFilter{}), []string{"open", "recent"}; !slices.Equal(got, want) {
+		t.Errorf("active tasks = %q, want %q", got, want)
+	}
+	if got, want := titles(t, service, models.TaskFilter{Archived: true}), []string{"old"}; !slices.Equal(got, want) {
+		t.Errorf("archived tasks = %q, want %q", got, want)
+	}
+
+	// The policy is applied as an operation, so it can be undone.
+	if _, err := undo.Undo(ctx, 1); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if got := titles(t, service, models.TaskFilter{Archived: true}); len(got) != 0 {
+		t.Errorf("archived tasks after undo = %q, want none", got)
+	}
+}
+
+func TestArchiveAndUnarchive(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	service := newTestTaskService(db)
+	done := createTask(t, service, &models.Task{Title: "done"})
+	open := createTask(t, service, &models.Task{Title: "open"})
+	completeTask(t, db, service, done, 1)
+
+	archived, err := service.ArchiveTasks(ctx, []int{done, open})
+	if err != nil {
+		t.Fatalf("ArchiveTasks returned error: %v", err)
+	}
+	if want := []int{done}; !slices.Equal(archived, want) {
+		t.Errorf("ArchiveTasks archived %v, want only the completed task %v", archived, want)
+	}
+
+	if _, err := service.UnarchiveTasks(ctx, []int{open}); !errors.Is(err, ErrTaskNotArchived) {
+		t.Errorf("unarchiving an active task returned error %v, want %v", err, ErrTaskNotArchived)
+	}
+
+	// Reopening an archived task brings it back to the active list.
+	if err := setStatus(service, done, constants.StatusPending); err != nil {
+		t.Fatalf("reopening the task returned error: %v", err)
+	}
+	if task := getTask(t, service, done); task.ArchivedAt != nil || task.CompletedAt != nil {
+		t.Errorf("reopened task archived at %v and completed at %v, want neither", task.ArchivedAt, task.CompletedAt)
+	}
+}
+
+func TestRemapPriorities(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	low := createTask(t, newTestTaskService(db), &models.Task{Title: "low", Priority: 1})
+	high := createTask(t, newTestTaskService(db), &models.Task{Title: "high", Priority: 3})
+
+	scale := models.PriorityScale{Levels: []models.PriorityLevel{{Name: "P4"}, {Name: "P3"}, {Name: "P2"}, {Name: "P1"}, {Name: "P0"}}}
+	service := NewTaskService(repositories.NewTaskRepository(db), repositories.NewOperationRepository(db), repositories.NewStatusRepository(db),
+		scale, models.DefaultUrgencyCoefficients())
+
+	changed, err := service.RemapPriorities(ctx, models.DefaultPriorityScale())
+	if err != nil {
+		t.Fatalf("RemapPriorities returned error: %v", err)
+	}
+	if want := []int{high}; !slices.Equal(changed, want) {
+		t.Errorf("RemapPriorities changed %v, want %v", changed, want)
+	}
+	if task := getTask(t, service, low); task.Priority != 1 {
+		t.Errorf("low priority = %d, want 1", task.Priority)
+	}
+	if task := getTask(t, service, high); task.Priority != 5 {
+		t.Errorf("high priority = %d, want 5", task.Priority)
+	}
+
+	if err := service.CreateTask(ctx, &models.Task{Title: "out of scale", Priority: 6}); !errors.Is(err, ErrInvalidPriority) {
+		t.Errorf("creating a task outside the scale returned error %v, want %v", err, ErrInvalidPriority)
+	}
+}
+
+func TestGetNextTasks(t *testing.T) {
+	ctx := context.Background()
+	service := newTestTaskService(newTestDB(t))
+	overdue := time.Now().AddDate(0, 0, -7)
+	low := createTask(t, service, &models.Task{Title: "low", Priority: 1})
+	createTask(t, service, &models.Task{Title: "high", Priority: 3})
+	createTask(t, service, &models.Task{Title: "overdue", Priority: 1, DueAt: &overdue})
+	done := createTask(t, service, &models.Task{Title: "done", Priority: 3})
+	blocked := createTask(t, service, &models.Task{Title: "blocked", Priority: 3})
+	if err := setStatus(service, done, constants.StatusCompleted); err != nil {
+		t.Fatalf("completing a task returned error: %v", err)
+	}
+	if err := service.AddDependencies(ctx, blocked, []int{low}); err != nil {
+		t.Fatalf("AddDependencies returned error: %v", err)
+	}
+
+	tasks, err := service.GetNextTasks(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetNextTasks returned error: %v", err)
+	}
+
+	var got []string
+	for _, task := range tasks {
+		got = append(got, task.Title)
+	}
+	if want := []string{"overdue", "high"}; !slices.Equal(got, want) {
+		t.Errorf("GetNextTasks = %q, want %q", got, want)
+	}
+}
+
+func TestSnoozeTask(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	service := newTestTaskService(db)
+	id := createTask(t, service, &models.Task{Title: "later"})
+	createTask(t, service, &models.Task{Title: "now"})
+
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(24*time.Hour)
+	if err := service.SnoozeTask(ctx, id, &past); !errors.Is(err, ErrSnoozeInPast) {
+		t.Errorf("snoozing until the past returned error %v, want %v", err, ErrSnoozeInPast)
+	}
+
+	if err := service.SnoozeTask(ctx, id, &future); err != nil {
+		t.Fatalf("SnoozeTask returned error: %v", err)
+	}
+	if got, want := titles(t, service, models.TaskFilter{}), []string{"now"}; !slices.Equal(got, want) {
+		t.Errorf("actionable tasks = %q, want %q", got, want)
+	}
+	if got, want := titles(t, service, models.TaskFilter{Waiting: true}), []string{"later"}; !slices.Equal(got, want) {
+		t.Errorf("snoozed tasks = %q, want %q", got, want)
+	}
+
+	if err := service.SnoozeTask(ctx, id, nil); err != nil {
+		t.Fatalf("waking the task returned error: %v", err)
+	}
+	if task := getTask(t, service, id); task.WaitUntil != nil {
+		t.Errorf("woken task is snoozed until %v", task.WaitUntil)
+	}
+
+	done := createTask(t, service, &models.Task{Title: "done"})
+	if err := setStatus(service, done, constants.StatusCompleted); err != nil {
+		t.Fatalf("completing a task returned error: %v", err)
+	}
+	if err := service.SnoozeTask(ctx, done, &future); !errors.Is(err, ErrSnoozeDone) {
+		t.Errorf("snoozing a finished task returned error %v, want %v", err, ErrSnoozeDone)
+	}
+}
+
+func TestResurfaceTask(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	service := newTestTaskService(db)
+	id := createTask(t, service, &models.Task{Title: "snoozed"})
+	until := time.Now().Add(time.Hour)
+	if err := service.SnoozeTask(ctx, id, &until); err != nil {
+		t.Fatalf("SnoozeTask returned error: %v", err)
+	}
+
+	// Let the snooze end.
+	if _, err := db.Exec("UPDATE tasks SET wait_until = ? WHERE id = ?", time.Now().Add(-time.Minute).UTC(), id); err != nil {
+		t.Fatalf("failed to end the snooze: %v", err)
+	}
+
+	countEvents := func() int {
+		t.Helper()
+		var events int
+		if err := db.QueryRow("SELECT COUNT(*) FROM task_events").Scan(&events); err != nil {
+			t.Fatalf("failed to count task events: %v", err)
+		}
+		return events
+	}
+	before := countEvents()
+
+	if got, want := titles(t, service, models.TaskFilter{}), []string{"snoozed"}; !slices.Equal(got, want) {
+		t.Errorf("actionable tasks = %q, want %q", got, want)
+	}
+	if task := getTask(t, service, id); task.Resurfaced(time.Now()) == nil {
+		t.Error("task whose snooze ended is not resurfaced")
+	}
+	if after := countEvents(); after != before {
+		t.Errorf("listing tasks recorded %d changes, want none", after-before)
+	}
+
+	// The next change to the task clears the ended snooze.
+	if err := setStatus(service, id, constants.StatusInProgress); err != nil {
+		t.Fatalf("starting the task returned error: %v", err)
+	}
+	if task := getTask(t, service, id); task.WaitUntil != nil || task.Resurfaced(time.Now()) != nil {
+		t.Errorf("updated task is snoozed until %v and resurfaced at %v, want neither", task.WaitUntil, task.Resurfaced(time.Now()))
+	}
+}
+
+func TestCreateTasks(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	service := newTestTaskService(db)
+	undo := NewUndoService(repositories.NewOperationRepository(db))
+
+	tasks := []*models.Task{
+		{Title: "first", Tags: []string{"Work"}},
+		{Title: "second", Tags: []string{"work", "home"}, Recurrence: "FREQ=WEEKLY;COUNT=3"},
+		{Title: "third"},
+	}
+	if err := service.CreateTasks(ctx, tasks); err != nil {
+		t.Fatalf("CreateTasks returned error: %v", err)
+	}
+	for _, task := range tasks {
+		if created := getTask(t, service, task.ID); created.Title != task.Title {
+			t.Errorf("task %d is %q, want %q", task.ID, created.Title, task.Title)
+		}
+	}
+	if got, want := titles(t, service, models.TaskFilter{AllTags: []string{"work"}}), []string{"first", "second"}; !slices.Equal(got, want) {
+		t.Errorf("tasks tagged work = %q, want %q", got, want)
+	}
+
+	// The tasks are created as one operation, so a single undo removes them all.
+	if _, err := undo.Undo(ctx, 1); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if got := titles(t, service, models.TaskFilter{}); len(got) != 0 {
+		t.Errorf("tasks after undo = %q, want none", got)
+	}
+}
+
+func TestCreateTasksInvalid(t *testing.T) {
+	service := newTestTaskService(newTestDB(t))
+
+	err := service.CreateTasks(context.Background(), []*models.Task{
+		{Title: "fine"},
+		{Title: "also fine"},
+		{Title: "bad repeat", Recurrence: "FREQ=SOMETIMES"},
+	})
+
+	var bulkErr *BulkTaskError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("CreateTasks returned error %v, want a *BulkTaskError", err)
+	}
+	if bulkErr.Index != 2 || !errors.Is(err, ErrInvalidRecurrence) {
+		t.Errorf("CreateTasks failed on task %d with %v, want task 2 with %v", bulkErr.Index, bulkErr.Err, ErrInvalidRecurrence)
+	}
+	if got := titles(t, service, models.TaskFilter{}); len(got) != 0 {
+		t.Errorf("tasks after the failed import = %q, want none", got)
+	}
+}
+
+func TestCompleteRecurringTask(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	service := newTestTaskService(db)
+	undo := NewUndoService(repositories.NewOperationRepository(db))
+	due := time.Now().Add(time.Hour).Truncate(time.Second)
+	id := createTask(t, service, &models.Task{Title: "water plants", Tags: []string{"home"}, DueAt: &due, Recurrence: "FREQ=WEEKLY;COUNT=2"})
+
+	if err := setStatus(service, id, constants.StatusCompleted); err != nil {
+		t.Fatalf("completing the task returned error: %v", err)
+	}
+	if task := getTask(t, service, id); task.Recurrence != "" {
+		t.Errorf("completed task still repeats %q", task.Recurrence)
+	}
+
+	tasks, err := service.GetAllTasks(ctx, models.TaskFilter{Status: constants.StatusPending})
+	if err != nil {
+		t.Fatalf("GetAllTasks returned error: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("completing the task left %d pending tasks, want the next occurrence", len(tasks))
+	}
+	next := tasks[0]
+	if want := due.AddDate(0, 0, 7); next.DueAt == nil || !next.DueAt.Equal(want) {
+		t.Errorf("next occurrence due at %v, want %v", next.DueAt, want)
+	}
+	if next.Recurrence != "FREQ=WEEKLY;COUNT=1" || !slices.Equal(next.Tags, []string{"home"}) {
+		t.Errorf("next occurrence repeats %q with tags %q", next.Recurrence, next.Tags)
+	}
+
+	// The completion and the next occurrence are one operation.
+	if _, err := undo.Undo(ctx, 1); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if got, want := titles(t, service, models.TaskFilter{}), []string{"water plants"}; !slices.Equal(got, want) {
+		t.Errorf("tasks after undo = %q, want %q", got, want)
+	}
+	if task := getTask(t, service, id); task.IsDone() {
+		t.Error("task is still completed after undo")
+	}
+}
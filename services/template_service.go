@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kkumar-gcc/todo/dates"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/repositories"
+)
+
+var (
+	ErrTemplateNotFound     = errors.New("template not found")
+	ErrEmptyTemplate        = errors.New("template has no tasks")
+	ErrInvalidTemplate      = errors.New("template is not valid")
+	ErrMissingVariable      = errors.New("template variables are not set, give them with --var NAME=VALUE")
+	ErrUnknownVariable      = errors.New("template does not declare these variables")
+	ErrTemplatesUnavailable = errors.New("failed to read the templates")
+)
+
+type TemplateService interface {
+	GetAllTemplates(ctx context.Context) ([]models.Template, error)
+	GetTemplate(ctx context.Context, name string) (*models.Template, error)
+	ApplyTemplate(ctx context.Context, name string, variables map[string]string, start time.Time) ([]*models.Task, error)
+}
+
+type TemplateServiceImpl struct {
+	repository repositories.TemplateRepository
+	tasks      TaskService
+	projects   ProjectService
+	priorities models.PriorityScale
+}
+
+// NewTemplateService creates a new instance of TemplateService
+func NewTemplateService(repo repositories.TemplateRepository, tasks TaskService, projects ProjectService, priorities models.PriorityScale) TemplateService {
+	return &TemplateServiceImpl{
+		repository: repo,
+		tasks:      tasks,
+		projects:   projects,
+		priorities: priorities,
+	}
+}
+
+func (r *TemplateServiceImpl) GetAllTemplates(ctx context.Context) ([]models.Template, error) {
+	templates, err := r.repository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTemplatesUnavailable, err)
+	}
+	return templates, nil
+}
+
+func (r *TemplateServiceImpl) GetTemplate(ctx context.Context, name string) (*models.Template, error) {
+	tmpl, err := r.repository.GetByName(ctx, name)
+	if errors.Is(err, repositories.ErrTemplateNotFound) {
+		return nil, fmt.Errorf("%w: %q", ErrTemplateNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTemplatesUnavailable, err)
+	}
+	return tmpl, nil
+}
+
+// ApplyTemplate creates the tasks of a template, filling in its variables and counting relative due dates
+// from start. Either every task of the template is created or, when one of them is not valid, none are.
+// The created tasks are returned parents first.
+func (r *TemplateServiceImpl) ApplyTemplate(ctx context.Context, name string, variables map[string]string, start time.Time) ([]*models.Task, error) {
+	tmpl, err := r.GetTemplate(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(tmpl.Tasks) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrEmptyTemplate, tmpl.Path)
+	}
+	if err := checkVariables(tmpl, variables); err != nil {
+		return nil, err
+	}
+
+	var projectID *int
+	if tmpl.Project != "" {
+		project, err := r.projects.GetProject(ctx, tmpl.Project)
+		if err != nil {
+			return nil, err
+		}
+		projectID = &project.ID
+	}
+
+	renderer := &templateRenderer{
+		variables:  variables,
+		start:      start,
+		priorities: r.priorities,
+		projectID:  projectID,
+	}
+	nodes, err := renderer.render(tmpl.Tasks, "tasks")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidTemplate, tmpl.Path, err)
+	}
+
+	if err := r.tasks.CreateTaskTree(ctx, fmt.Sprintf("apply template %q", tmpl.Name), nodes); err != nil {
+		return nil, err
+	}
+
+	return flattenTree(nodes), nil
+}
+
+// checkVariables makes sure every variable the template declares is given and, when it declares any,
+// that no others are, which catches misspelt names.
+func checkVariables(tmpl *models.Template, variables map[string]string) error {
+	var missing, unknown []string
+	for _, name := range tmpl.Variables {
+		if _, ok := variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(tmpl.Variables) > 0 {
+		for name := range variables {
+			if !slices.Contains(tmpl.Variables, name) {
+				unknown = append(unknown, name)
+			}
+		}
+	}
+
+	switch {
+	case len(missing) > 0:
+		return fmt.Errorf("%w: %s", ErrMissingVariable, strings.Join(missing, ", "))
+	case len(unknown) > 0:
+		slices.Sort(unknown)
+		return fmt.Errorf("%w: %s, expected: %s", ErrUnknownVariable, strings.Join(unknown, ", "), strings.Join(tmpl.Variables, ", "))
+	}
+	return nil
+}
+
+// templateRenderer turns the tasks of a template into tasks ready to be created.
+type templateRenderer struct {
+	variables  map[string]string
+	start      time.Time
+	priorities models.PriorityScale
+	projectID  *int
+}
+
+func (r *templateRenderer) render(tasks []models.TemplateTask, path string) ([]models.TaskNode, error) {
+	nodes := make([]models.TaskNode, len(tasks))
+	for i, task := range tasks {
+		taskPath := path + "[" + strconv.Itoa(i) + "]"
+		created, err := r.task(task, taskPath)
+		if err != nil {
+			return nil, err
+		}
+		subtasks, err := r.render(task.Subtasks, taskPath+".subtasks")
+		if err != nil {
+			return nil, err
+		}
+		nodes[i] = models.TaskNode{Task: created, Subtasks: subtasks}
+	}
+	return nodes, nil
+}
+
+func (r *templateRenderer) task(task models.TemplateTask, path string) (*models.Task, error) {
+	title, err := r.text(task.Title, path+".title")
+	if err != nil {
+		return nil, err
+	}
+	if title = strings.TrimSpace(title); title == "" {
+		return nil, fmt.Errorf("%s: %w", path+".title", ErrEmptyTitle)
+	}
+
+	notes, err := r.text(task.Notes, path+".notes")
+	if err != nil {
+		return nil, err
+	}
+
+	var priority int
+	if task.Priority != "" {
+		if priority = r.priorities.Resolve(task.Priority); priority == 0 {
+			return nil, fmt.Errorf("%s.priority: %w: %q, expected one of: %s", path, ErrInvalidPriority, task.Priority, strings.Join(r.priorities.Names(), ", "))
+		}
+	}
+
+	due, err := r.text(task.Due, path+".due")
+	if err != nil {
+		return nil, err
+	}
+	var dueAt *time.Time
+	if due = strings.TrimSpace(due); due != "" {
+		at, err := dates.Parse(due, r.start)
+		if err != nil {
+			return nil, fmt.Errorf("%s.due: %w", path, err)
+		}
+		dueAt = &at
+	}
+
+	estimate, err := models.ParseEstimate(task.Estimate)
+	if err != nil {
+		return nil, fmt.Errorf("%s.estimate: %w", path, err)
+	}
+
+	return &models.Task{
+		Title:     title,
+		Notes:     strings.TrimRight(notes, "\n"),
+		Priority:  priority,
+		Tags:      task.Tags,
+		DueAt:     dueAt,
+		ProjectID: r.projectID,
+		Estimate:  estimate,
+	}, nil
+}
+
+// text fills the template variables into a piece of text. Referring to a variable that was not given is an error.
+func (r *templateRenderer) text(text, path string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	parsed, err := template.New(path).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var rendered strings.Builder
+	if err := parsed.Execute(&rendered, r.variables); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+// flattenTree lists the tasks of a tree, each task before its subtasks.
+func flattenTree(nodes []models.TaskNode) []*models.Task {
+	var tasks []*models.Task
+	for _, node := range nodes {
+		tasks = append(tasks, node.Task)
+		tasks = append(tasks, flattenTree(node.Subtasks)...)
+	}
+	return tasks
+}
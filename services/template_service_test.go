@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/repositories"
+)
+
+func TestCheckVariables(t *testing.T) {
+	release := &models.Template{Variables: []string{"Version", "Owner"}}
+
+	tests := []struct {
+		name      string
+		template  *models.Template
+		variables map[string]string
+		want      error
+	}{
+		{name: "all given", template: release, variables: map[string]string{"Version": "1.4", "Owner": "sam"}},
+		{name: "missing", template: release, variables: map[string]string{"Version": "1.4"}, want: ErrMissingVariable},
+		{name: "misspelt", template: release, variables: map[string]string{"Version": "1.4", "Owner": "sam", "Ownr": "sam"}, want: ErrUnknownVariable},
+		{name: "undeclared", template: &models.Template{}, variables: map[string]string{"Anything": "goes"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := checkVariables(test.template, test.variables); !errors.Is(err, test.want) {
+				t.Errorf("checkVariables returned error %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestTemplateRenderer(t *testing.T) {
+	start := time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC)
+	renderer := &templateRenderer{
+		variables:  map[string]string{"Version": "1.4"},
+		start:      start,
+		priorities: models.DefaultPriorityScale(),
+	}
+
+	nodes, err := renderer.render([]models.TemplateTask{{
+		Title:    "Release {{.Version}}",
+		Notes:    "Ship {{.Version}}\n\n",
+		Priority: "high",
+		Due:      "+3d",
+		Tags:     []string{"release"},
+		Subtasks: []models.TemplateTask{{Title: "Tag v{{.Version}}", Estimate: "30m"}},
+	}}, "tasks")
+	if err != nil {
+		t.Fatalf("render returned error: %v", err)
+	}
+
+	task := nodes[0].Task
+	if task.Title != "Release 1.4" || task.Notes != "Ship 1.4" || task.Priority != 3 {
+		t.Errorf("task = %q, notes %q, priority %d", task.Title, task.Notes, task.Priority)
+	}
+	if want := start.AddDate(0, 0, 3); task.DueAt == nil || !task.DueAt.Equal(want) {
+		t.Errorf("due at %v, want %v", task.DueAt, want)
+	}
+	if len(nodes[0].Subtasks) != 1 {
+		t.Fatalf("task has %d subtasks, want 1", len(nodes[0].Subtasks))
+	}
+	if subtask := nodes[0].Subtasks[0].Task; subtask.Title != "Tag v1.4" || subtask.Estimate.Duration != 30*time.Minute {
+		t.Errorf("subtask = %q estimated at %v", subtask.Title, subtask.Estimate)
+	}
+
+	invalid := map[string]models.TemplateTask{
+		"unknown variable": {Title: "Release {{.Codename}}"},
+		"empty title":      {Title: "{{if false}}x{{end}}"},
+		"unknown priority": {Title: "Release", Priority: "urgent"},
+		"invalid due date": {Title: "Release", Due: "someday"},
+		"invalid estimate": {Title: "Release", Estimate: "soon"},
+	}
+	for name, task := range invalid {
+		if _, err := renderer.render([]models.TemplateTask{task}, "tasks"); err == nil {
+			t.Errorf("render accepted a task with an %s", name)
+		}
+	}
+}
+
+func TestApplyTemplate(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	release := `variables: [Version]
+tasks:
+  - title: Release {{.Version}}
+    subtasks:
+      - title: Write notes for {{.Version}}
+      - title: Tag {{.Version}}
+`
+	broken := `tasks:
+  - title: Fine
+  - title: Broken
+    priority: urgent
+`
+	for name, contents := range map[string]string{"release.yaml": release, "broken.yaml": broken} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644); err != nil {
+			t.Fatalf("failed to write template: %v", err)
+		}
+	}
+
+	tasks := newTestTaskService(newTestDB(t))
+	service := NewTemplateService(repositories.NewTemplateRepository(dir), tasks, nil, models.DefaultPriorityScale())
+
+	created, err := service.ApplyTemplate(ctx, "release", map[string]string{"Version": "1.4"}, time.Now())
+	if err != nil {
+		t.Fatalf("ApplyTemplate returned error: %v", err)
+	}
+	if len(created) != 3 || created[1].ParentID == nil || *created[1].ParentID != created[0].ID {
+		t.Fatalf("ApplyTemplate created %d tasks, want a parent and its 2 subtasks", len(created))
+	}
+
+	if _, err := service.ApplyTemplate(ctx, "release", nil, time.Now()); !errors.Is(err, ErrMissingVariable) {
+		t.Errorf("applying without the variables returned error %v, want %v", err, ErrMissingVariable)
+	}
+	if _, err := service.ApplyTemplate(ctx, "missing", nil, time.Now()); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("applying a missing template returned error %v, want %v", err, ErrTemplateNotFound)
+	}
+	if _, err := service.ApplyTemplate(ctx, "broken", nil, time.Now()); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("applying a broken template returned error %v, want %v", err, ErrInvalidTemplate)
+	}
+
+	want := []string{"Release 1.4", "Tag 1.4", "Write notes for 1.4"}
+	if got := titles(t, tasks, models.TaskFilter{}); !slices.Equal(got, want) {
+		t.Errorf("tasks = %q, want %q", got, want)
+	}
+}
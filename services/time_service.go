@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kkumar-gcc/todo/constants"
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/repositories"
+)
+
+var (
+	ErrNoRunningTimer      = errors.New("no timer is running")
+	ErrTimerAlreadyRunning = errors.New("a timer is already running on this task")
+	ErrInvalidDuration     = errors.New("duration must be greater than zero")
+	ErrTimeEntryInFuture   = errors.New("time entries cannot end in the future")
+	ErrTimeTrackingFailed  = errors.New("failed to track time")
+)
+
+type TimeService interface {
+	StartTimer(ctx context.Context, taskID int, opts ...UpdateOption) (*models.TimeEntry, error)
+	StopTimer(ctx context.Context) (*models.TimeEntry, error)
+	LogTime(ctx context.Context, taskID int, duration time.Duration, endedAt time.Time, note string) (*models.TimeEntry, error)
+	GetRunningTimer(ctx context.Context) (*models.TimeEntry, error)
+	GetTimeEntries(ctx context.Context, taskID int) ([]models.TimeEntry, error)
+	StartFocus(ctx context.Context, taskID int, opts ...UpdateOption) (*models.TimeEntry, error)
+	RecordPomodoro(ctx context.Context, pomodoro *models.Pomodoro) error
+	CountPomodoros(ctx context.Context, taskID int) (*models.PomodoroCount, error)
+}
+
+type TimeServiceImpl struct {
+	repository  repositories.TimeEntryRepository
+	pomodoros   repositories.PomodoroRepository
+	taskService TaskService
+}
+
+// NewTimeService creates a new instance of TimeService
+func NewTimeService(repo repositories.TimeEntryRepository, pomodoros repositories.PomodoroRepository, taskService TaskService) TimeService {
+	return &TimeServiceImpl{
+		repository:  repo,
+		pomodoros:   pomodoros,
+		taskService: taskService,
+	}
+}
+
+// StartTimer starts tracking time on a task and moves it to in progress. Only one timer runs at a time,
+// so a timer running on another task is stopped first and its entry returned.
+func (r *TimeServiceImpl) StartTimer(ctx context.Context, taskID int, opts ...UpdateOption) (*models.TimeEntry, error) {
+	task, err := r.taskService.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Tracking {
+		return nil, ErrTimerAlreadyRunning
+	}
+
+	if err := r.moveToInProgress(ctx, task, opts...); err != nil {
+		return nil, err
+	}
+
+	stopped, err := r.repository.Start(ctx, taskID, time.Now())
+	if err != nil {
+		return nil, ErrTimeTrackingFailed
+	}
+
+	return stopped, nil
+}
+
+// StartFocus prepares a task for a series of pomodoros: it moves the task to in progress and stops the
+// running timer, returning its entry, since the pomodoros record their own time.
+func (r *TimeServiceImpl) StartFocus(ctx context.Context, taskID int, opts ...UpdateOption) (*models.TimeEntry, error) {
+	task, err := r.taskService.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.moveToInProgress(ctx, task, opts...); err != nil {
+		return nil, err
+	}
+
+	stopped, err := r.StopTimer(ctx)
+	if errors.Is(err, ErrNoRunningTimer) {
+		return nil, nil
+	}
+	return stopped, err
+}
+
+// RecordPomodoro records a focus session on a task, along with a time entry for the time it took.
+func (r *TimeServiceImpl) RecordPomodoro(ctx context.Context, pomodoro *models.Pomodoro) error {
+	if pomodoro.EndedAt.Before(pomodoro.StartedAt) || pomodoro.Planned <= 0 {
+		return ErrInvalidDuration
+	}
+
+	note := "Pomodoro"
+	if !pomodoro.Completed {
+		note = "Pomodoro (interrupted)"
+	}
+	if err := r.pomodoros.Create(ctx, pomodoro, note); err != nil {
+		return ErrTimeTrackingFailed
+	}
+
+	return nil
+}
+
+func (r *TimeServiceImpl) CountPomodoros(ctx context.Context, taskID int) (*models.PomodoroCount, error) {
+	if taskID <= 0 {
+		return nil, ErrInvalidID
+	}
+
+	return r.pomodoros.CountByTaskID(ctx, taskID)
+}
+
+// moveToInProgress starts a task that is not in progress yet, which is refused for blocked tasks unless forced.
+func (r *TimeServiceImpl) moveToInProgress(ctx context.Context, task *models.Task, opts ...UpdateOption) error {
+	if task.StatusCategory == models.CategoryActive {
+		return nil
+	}
+
+	return r.taskService.UpdateTask(ctx, task.ID, func(task *models.Task) (*models.Task, error) {
+		task.Status = constants.StatusInProgress
+		return task, nil
+	}, opts...)
+}
+
+// StopTimer stops the running timer and returns its entry.
+func (r *TimeServiceImpl) StopTimer(ctx context.Context) (*models.TimeEntry, error) {
+	stopped, err := r.repository.Stop(ctx, time.Now())
+	if errors.Is(err, repositories.ErrNoRunningTimer) {
+		return nil, ErrNoRunningTimer
+	}
+	if err != nil {
+		return nil, ErrTimeTrackingFailed
+	}
+
+	return stopped, nil
+}
+
+// LogTime records time spent on a task without a timer, as an entry of the given duration ending at endedAt.
+func (r *TimeServiceImpl) LogTime(ctx context.Context, taskID int, duration time.Duration, endedAt time.Time, note string) (*models.TimeEntry, error) {
+	if duration <= 0 {
+		return nil, ErrInvalidDuration
+	}
+	if endedAt.After(time.Now()) {
+		return nil, ErrTimeEntryInFuture
+	}
+
+	task, err := r.taskService.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &models.TimeEntry{
+		TaskID:    task.ID,
+		StartedAt: endedAt.Add(-duration),
+		EndedAt:   &endedAt,
+		Note:      note,
+		Task:      task.Title,
+	}
+	if err := r.repository.Create(ctx, entry); err != nil {
+		return nil, ErrTimeTrackingFailed
+	}
+
+	return entry, nil
+}
+
+// GetRunningTimer returns the entry of the running timer, or ErrNoRunningTimer.
+func (r *TimeServiceImpl) GetRunningTimer(ctx context.Context) (*models.TimeEntry, error) {
+	entry, err := r.repository.GetRunning(ctx)
+	if errors.Is(err, repositories.ErrNoRunningTimer) {
+		return nil, ErrNoRunningTimer
+	}
+	return entry, err
+}
+
+func (r *TimeServiceImpl) GetTimeEntries(ctx context.Context, taskID int) ([]models.TimeEntry, error) {
+	if taskID <= 0 {
+		return nil, ErrInvalidID
+	}
+
+	return r.repository.GetByTaskID(ctx, taskID)
+}
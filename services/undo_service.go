@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/repositories"
+)
+
+var (
+	ErrInvalidSteps  = errors.New("steps must be a positive integer")
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrNothingToRedo = errors.New("nothing to redo")
+	ErrUndoFailed    = errors.New("failed to undo the operation")
+	ErrRedoFailed    = errors.New("failed to redo the operation")
+	ErrTrackedTime   = errors.New("time was tracked on a task the operation would delete, so it cannot be reverted without losing that time")
+)
+
+type UndoService interface {
+	Undo(ctx context.Context, steps int) ([]models.Operation, error)
+	Redo(ctx context.Context, steps int) ([]models.Operation, error)
+	GetRecentOperations(ctx context.Context, limit int) ([]models.Operation, error)
+}
+
+type UndoServiceImpl struct {
+	repository repositories.OperationRepository
+}
+
+// NewUndoService creates a new instance of UndoService
+func NewUndoService(repo repositories.OperationRepository) UndoService {
+	return &UndoServiceImpl{
+		repository: repo,
+	}
+}
+
+// Undo reverts up to the given number of operations, most recent first, and returns the ones reverted.
+func (r *UndoServiceImpl) Undo(ctx context.Context, steps int) ([]models.Operation, error) {
+	return r.replay(ctx, steps, r.repository.Undo, repositories.ErrNothingToUndo, ErrNothingToUndo, ErrUndoFailed)
+}
+
+// Redo reapplies up to the given number of undone operations, in the order they were first made.
+func (r *UndoServiceImpl) Redo(ctx context.Context, steps int) ([]models.Operation, error) {
+	return r.replay(ctx, steps, r.repository.Redo, repositories.ErrNothingToRedo, ErrNothingToRedo, ErrRedoFailed)
+}
+
+func (r *UndoServiceImpl) GetRecentOperations(ctx context.Context, limit int) ([]models.Operation, error) {
+	if limit <= 0 {
+		return nil, ErrInvalidSteps
+	}
+
+	return r.repository.GetRecent(ctx, limit)
+}
+
+func (r *UndoServiceImpl) replay(ctx context.Context, steps int, step func(ctx context.Context) (*models.Operation, error), errEmpty, errNothing, errFailed error) ([]models.Operation, error) {
+	if steps <= 0 {
+		return nil, ErrInvalidSteps
+	}
+
+	var operations []models.Operation
+	for len(operations) < steps {
+		operation, err := step(ctx)
+		if errors.Is(err, errEmpty) {
+			if len(operations) == 0 {
+				return nil, errNothing
+			}
+			break
+		}
+		if errors.Is(err, repositories.ErrTrackedTime) {
+			return operations, ErrTrackedTime
+		}
+		if err != nil {
+			return operations, errFailed
+		}
+		operations = append(operations, *operation)
+	}
+
+	return operations, nil
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kkumar-gcc/todo/models"
+	"github.com/kkumar-gcc/todo/repositories"
+)
+
+// rename changes the title of a task.
+func rename(t *testing.T, service TaskService, id int, title string) {
+	t.Helper()
+
+	err := service.UpdateTask(context.Background(), id, func(task *models.Task) (*models.Task, error) {
+		task.Title = title
+		return task, nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateTask(%d) returned error: %v", id, err)
+	}
+}
+
+func TestUndoAndRedo(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	tasks := newTestTaskService(db)
+	undo := NewUndoService(repositories.NewOperationRepository(db))
+
+	id := createTask(t, tasks, &models.Task{Title: "draft"})
+	rename(t, tasks, id, "final")
+
+	if _, err := undo.Undo(ctx, 1); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if task := getTask(t, tasks, id); task.Title != "draft" {
+		t.Errorf("title after undo = %q, want %q", task.Title, "draft")
+	}
+
+	if _, err := undo.Undo(ctx, 1); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if _, err := tasks.GetTaskByID(ctx, id); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("task lookup after undoing its creation returned error %v, want %v", err, ErrTaskNotFound)
+	}
+	if _, err := undo.Undo(ctx, 1); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo with an empty history returned error %v, want %v", err, ErrNothingToUndo)
+	}
+
+	redone, err := undo.Redo(ctx, 5)
+	if err != nil {
+		t.Fatalf("Redo returned error: %v", err)
+	}
+	if len(redone) != 2 {
+		t.Errorf("Redo redid %d operations, want 2", len(redone))
+	}
+	if task := getTask(t, tasks, id); task.Title != "final" {
+		t.Errorf("title after redo = %q, want %q", task.Title, "final")
+	}
+	if _, err := undo.Redo(ctx, 1); !errors.Is(err, ErrNothingToRedo) {
+		t.Errorf("Redo with nothing undone returned error %v, want %v", err, ErrNothingToRedo)
+	}
+}
+
+func TestNewChangeDiscardsRedo(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	tasks := newTestTaskService(db)
+	undo := NewUndoService(repositories.NewOperationRepository(db))
+
+	id := createTask(t, tasks, &models.Task{Title: "draft"})
+	rename(t, tasks, id, "final")
+	if _, err := undo.Undo(ctx, 1); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	rename(t, tasks, id, "other")
+
+	if _, err := undo.Redo(ctx, 1); !errors.Is(err, ErrNothingToRedo) {
+		t.Errorf("Redo after a new change returned error %v, want %v", err, ErrNothingToRedo)
+	}
+}
+
+func TestUndoKeepsTrackedTime(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	tasks := newTestTaskService(db)
+	undo := NewUndoService(repositories.NewOperationRepository(db))
+	times := NewTimeService(repositories.NewTimeEntryRepository(db), repositories.NewPomodoroRepository(db), tasks)
+
+	id := createTask(t, tasks, &models.Task{Title: "tracked"})
+	if _, err := times.LogTime(ctx, id, 30*time.Minute, time.Now(), ""); err != nil {
+		t.Fatalf("LogTime returned error: %v", err)
+	}
+
+	if _, err := undo.Undo(ctx, 1); !errors.Is(err, ErrTrackedTime) {
+		t.Fatalf("undoing the creation of a tracked task returned error %v, want %v", err, ErrTrackedTime)
+	}
+	if task := getTask(t, tasks, id); task.TrackedTime != 30*time.Minute {
+		t.Errorf("tracked time = %v, want 30m", task.TrackedTime)
+	}
+}
+
+func TestUndoInvalidSteps(t *testing.T) {
+	undo := NewUndoService(repositories.NewOperationRepository(newTestDB(t)))
+
+	if _, err := undo.Undo(context.Background(), 0); !errors.Is(err, ErrInvalidSteps) {
+		t.Errorf("Undo(0) returned error %v, want %v", err, ErrInvalidSteps)
+	}
+	if _, err := undo.Redo(context.Background(), -1); !errors.Is(err, ErrInvalidSteps) {
+		t.Errorf("Redo(-1) returned error %v, want %v", err, ErrInvalidSteps)
+	}
+}
+
+func TestUndoEmptyTrash(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	tasks := newTestTaskService(db)
+	undo := NewUndoService(repositories.NewOperationRepository(db))
+	times := NewTimeService(repositories.NewTimeEntryRepository(db), repositories.NewPomodoroRepository(db), tasks)
+
+	id := createTask(t, tasks, &models.Task{Title: "tracked"})
+	if _, err := times.LogTime(ctx, id, time.Hour, time.Now(), "review"); err != nil {
+		t.Fatalf("LogTime returned error: %v", err)
+	}
+	if err := tasks.DeleteTask(ctx, id, SubtasksReject); err != nil {
+		t.Fatalf("DeleteTask returned error: %v", err)
+	}
+	if _, err := tasks.EmptyTrash(ctx, 0); err != nil {
+		t.Fatalf("EmptyTrash returned error: %v", err)
+	}
+
+	if _, err := undo.Undo(ctx, 2); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if task := getTask(t, tasks, id); task.TrackedTime != time.Hour {
+		t.Errorf("tracked time after undoing the purge = %v, want 1h", task.TrackedTime)
+	}
+
+	if _, err := undo.Redo(ctx, 2); err != nil {
+		t.Fatalf("Redo returned error: %v", err)
+	}
+	if _, err := tasks.GetTaskByID(ctx, id); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("task lookup after redoing the purge returned error %v, want %v", err, ErrTaskNotFound)
+	}
+}